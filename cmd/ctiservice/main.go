@@ -6,6 +6,7 @@ import (
 	"ctiservice/internal/client"
 	"ctiservice/internal/config"
 	"ctiservice/internal/handler"
+	"ctiservice/internal/protocol"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -13,44 +14,53 @@ import (
 )
 
 func main() {
-	// Load configuration
-	cfg, err := config.LoadFromEnv()
+	// Load configuration, one per session
+	cfgs, err := config.LoadSessionsFromEnv()
 	if err != nil {
 		slog.Error("failed to load configuration", "error", err)
 		os.Exit(1)
 	}
 
 	// Validate configuration
-	if err := cfg.Validate(); err != nil {
-		slog.Error("invalid configuration", "error", err)
-		os.Exit(1)
+	for _, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			slog.Error("invalid configuration", sessionAttrs(cfgs, cfg, "error", err)...)
+			os.Exit(1)
+		}
 	}
 
 	// Setup logger
-	logLevel := parseLogLevel(cfg.LogLevel)
+	logLevel := parseLogLevel(cfgs[0].LogLevel)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: logLevel,
 	}))
 	slog.SetDefault(logger)
 
-	logger.Info("starting CTI service", "config", cfg.String())
+	for _, cfg := range cfgs {
+		logger.Info("starting CTI service", sessionAttrs(cfgs, cfg, "config", cfg.String())...)
+	}
 
-	// Create event handler
+	// Create event handler; events are tagged with their session only when
+	// there is more than one
 	eventHandler := handler.NewLogHandler(logger.With("component", "events"))
+	handleEvent := eventHandler.HandleSession
+	if len(cfgs) == 1 {
+		handleEvent = func(_ string, msg protocol.Message) {
+			eventHandler.Handle(msg)
+		}
+	}
 
 	// Create context with cancellation on SIGINT/SIGTERM
 	ctx, cancel := signal.NotifyContext(context.Background(),
 		syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	// Create and run the client
-	ctiClient := client.New(cfg, logger.With("component", "client"), eventHandler.Handle)
+	// Create and run the sessions
+	manager := client.NewManager(cfgs, logger.With("component", "client"), handleEvent)
 
-	logger.Info("connecting to CTI server",
-		"host", cfg.ServerHost,
-		"port", cfg.ServerPort)
+	logger.Info("connecting to CTI servers", "sessions", len(cfgs))
 
-	if err := ctiClient.Run(ctx); err != nil {
+	if err := manager.Run(ctx); err != nil {
 		if ctx.Err() != nil {
 			logger.Info("shutting down gracefully")
 		} else {
@@ -62,6 +72,15 @@ func main() {
 	logger.Info("CTI service stopped")
 }
 
+// sessionAttrs prepends the session name to attrs when several sessions
+// are configured.
+func sessionAttrs(cfgs []*config.Config, cfg *config.Config, attrs ...any) []any {
+	if len(cfgs) == 1 {
+		return attrs
+	}
+	return append([]any{"session", cfg.SessionName}, attrs...)
+}
+
 func parseLogLevel(level string) slog.Level {
 	switch level {
 	case "debug":
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"fmt"
+)
+
+// AgentStatus is an agent's state as reported by QUERY_AGENT_STATE_CONF.
+type AgentStatus struct {
+	AgentID                 string
+	AgentExtension          string
+	AgentInstrument         string
+	State                   uint16 // One of the protocol.AgentState* constants
+	MRDID                   int32
+	NumTasks                uint32
+	AgentMode               uint16
+	MaxTaskLimit            uint32
+	ICMAgentID              int32
+	AgentAvailabilityStatus uint32
+	DepartmentID            int32
+	SkillGroups             []messages.SkillGroup
+}
+
+// StateName returns the human-readable name for the agent state.
+func (s *AgentStatus) StateName() string {
+	return protocol.AgentStateName(s.State)
+}
+
+// IsLoggedIn reports whether the agent is in any state other than LoggedOut or Unknown.
+func (s *AgentStatus) IsLoggedIn() bool {
+	return s.State != protocol.AgentStateLoggedOut && s.State != protocol.AgentStateUnknown
+}
+
+// SetAgentState logs an agent in or out or changes its state, blocking until
+// the server confirms. The InvokeID is assigned by the client; PeripheralID
+// defaults to the session's peripheral when zero.
+func (c *Client) SetAgentState(ctx context.Context, req *messages.SetAgentStateReq) error {
+	req.InvokeID = c.session.NextInvokeID()
+	req.PeripheralID = c.peripheralID(req.PeripheralID)
+
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// QueryAgentState returns the current state of the agent identified by
+// agentID or, if agentID is empty, of the agent at the given instrument.
+func (c *Client) QueryAgentState(ctx context.Context, agentID, instrument string) (*AgentStatus, error) {
+	req := &messages.QueryAgentStateReq{
+		InvokeID:        c.session.NextInvokeID(),
+		PeripheralID:    c.peripheralID(0),
+		AgentID:         agentID,
+		AgentInstrument: instrument,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.QueryAgentStateConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to QUERY_AGENT_STATE_REQ: %T", resp)
+	}
+
+	return &AgentStatus{
+		AgentID:                 conf.AgentID,
+		AgentExtension:          conf.AgentExtension,
+		AgentInstrument:         conf.AgentInstrument,
+		State:                   conf.AgentState,
+		MRDID:                   conf.MRDID,
+		NumTasks:                conf.NumTasks,
+		AgentMode:               conf.AgentMode,
+		MaxTaskLimit:            conf.MaxTaskLimit,
+		ICMAgentID:              conf.ICMAgentID,
+		AgentAvailabilityStatus: conf.AgentAvailabilityStatus,
+		DepartmentID:            conf.DepartmentID,
+		SkillGroups:             conf.SkillGroups,
+	}, nil
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidDTMFDigits is returned by SendDTMF when the digit string is empty,
+// too long, or contains characters outside 0-9, *, #, A-D and the ',' pause.
+var ErrInvalidDTMFDigits = errors.New("invalid DTMF digits")
+
+// maxDTMFDigits is the maximum length of the DTMFString floating field.
+const maxDTMFDigits = 32
+
+// MakeCall places an outbound call and blocks until the server confirms it.
+// The InvokeID is assigned by the client; PeripheralID defaults to the
+// session's peripheral when zero. Both are set on a copy, so req can be reused
+// for a retry. Returns the ConnectionCallID of the new call.
+func (c *Client) MakeCall(ctx context.Context, req *messages.MakeCallReq) (uint32, error) {
+	r := *req
+	r.InvokeID = c.session.NextInvokeID()
+	r.PeripheralID = c.peripheralID(req.PeripheralID)
+
+	resp, err := c.request(ctx, r.InvokeID, &r)
+	if err != nil {
+		return 0, err
+	}
+
+	conf, ok := resp.(*messages.MakeCallConf)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response to MAKE_CALL_REQ: %T", resp)
+	}
+	return conf.NewConnectionCallID, nil
+}
+
+// AnswerCall answers the alerting call at the given connection. In agent mode
+// the request carries the configured AgentInstrument, as do ClearCall and
+// ClearConnection.
+func (c *Client) AnswerCall(ctx context.Context, conn protocol.ConnectionID) error {
+	req := &messages.AnswerCallReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+		AgentInstrument:        c.cfg.AgentInstrument,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// ClearCall releases every party from the call the connection belongs to.
+func (c *Client) ClearCall(ctx context.Context, conn protocol.ConnectionID) error {
+	req := &messages.ClearCallReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+		AgentInstrument:        c.cfg.AgentInstrument,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// ClearConnection releases only the given connection, leaving the other
+// parties on the call.
+func (c *Client) ClearConnection(ctx context.Context, conn protocol.ConnectionID) error {
+	req := &messages.ClearConnectionReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+		AgentInstrument:        c.cfg.AgentInstrument,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// AlternateCall places the active call on hold and retrieves the held call,
+// toggling between the two legs of a consult.
+func (c *Client) AlternateCall(ctx context.Context, active, held protocol.ConnectionID) error {
+	req := &messages.AlternateCallReq{
+		InvokeID:                 c.session.NextInvokeID(),
+		PeripheralID:             c.peripheralID(0),
+		ActiveConnectionCallID:   active.CallID,
+		ActiveConnectionType:     active.DeviceIDType,
+		HeldConnectionCallID:     held.CallID,
+		HeldConnectionType:       held.DeviceIDType,
+		ActiveConnectionDeviceID: active.DeviceID,
+		HeldConnectionDeviceID:   held.DeviceID,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// ReconnectCall clears the active consult call and retrieves the held call,
+// returning the agent to the original caller.
+func (c *Client) ReconnectCall(ctx context.Context, active, held protocol.ConnectionID) error {
+	req := &messages.ReconnectCallReq{
+		InvokeID:                 c.session.NextInvokeID(),
+		PeripheralID:             c.peripheralID(0),
+		ActiveConnectionCallID:   active.CallID,
+		ActiveConnectionType:     active.DeviceIDType,
+		HeldConnectionCallID:     held.CallID,
+		HeldConnectionType:       held.DeviceIDType,
+		ActiveConnectionDeviceID: active.DeviceID,
+		HeldConnectionDeviceID:   held.DeviceID,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// SetCallData writes call variables, wrap-up data and ECC variables to the
+// call at the given connection. Only the fields set in data are sent.
+func (c *Client) SetCallData(ctx context.Context, conn protocol.ConnectionID, data messages.CallDataUpdate) error {
+	req := &messages.SetCallDataReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+		CallDataUpdate:         data,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// SendDTMF plays the given DTMF digits on the call at the given connection,
+// using the peripheral's default tone and pause durations. The digits are
+// validated before anything is sent; a rejection by the server is returned as
+// a *CTIError.
+func (c *Client) SendDTMF(ctx context.Context, conn protocol.ConnectionID, digits string) error {
+	return c.SendDTMFTimed(ctx, conn, digits, 0, 0)
+}
+
+// SendDTMFTimed is SendDTMF with explicit tone and inter-digit pause
+// durations, sent in milliseconds. Zero leaves a duration to the peripheral.
+func (c *Client) SendDTMFTimed(ctx context.Context, conn protocol.ConnectionID, digits string, tone, pause time.Duration) error {
+	if err := validateDTMF(digits); err != nil {
+		return err
+	}
+	if tone < 0 || pause < 0 {
+		return fmt.Errorf("invalid DTMF tone or pause duration: %v, %v", tone, pause)
+	}
+
+	req := &messages.SendDTMFSignalReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ToneDuration:           uint32(tone.Milliseconds()),
+		PauseDuration:          uint32(pause.Milliseconds()),
+		ConnectionDeviceID:     conn.DeviceID,
+		DTMFString:             digits,
+		AgentInstrument:        c.cfg.AgentInstrument,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
+
+// validateDTMF checks digits against the DTMF alphabet and length limit.
+func validateDTMF(digits string) error {
+	if digits == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidDTMFDigits)
+	}
+	if len(digits) > maxDTMFDigits {
+		return fmt.Errorf("%w: %d digits exceeds maximum of %d", ErrInvalidDTMFDigits, len(digits), maxDTMFDigits)
+	}
+	if i := strings.IndexFunc(digits, func(r rune) bool {
+		return !strings.ContainsRune("0123456789*#ABCD,", r)
+	}); i >= 0 {
+		r, _ := utf8.DecodeRuneInString(digits[i:])
+		return fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidDTMFDigits, r, i)
+	}
+	return nil
+}
+
+// ReportBadCall flags the call at the given connection as having poor line
+// quality so the network team can trace it.
+func (c *Client) ReportBadCall(ctx context.Context, conn protocol.ConnectionID) error {
+	req := &messages.BadCallReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+		AgentInstrument:        c.cfg.AgentInstrument,
+	}
+	_, err := c.request(ctx, req.InvokeID, req)
+	return err
+}
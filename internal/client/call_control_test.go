@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMakeCallLeavesRequestUnchanged(t *testing.T) {
+	c, server := newTestClient(t, nil)
+	go func() {
+		for range 2 {
+			req, ok := serverRead(t, server).(*messages.MakeCallReq)
+			if !ok {
+				return
+			}
+			serverWrite(t, server, &messages.MakeCallConf{InvokeID: req.InvokeID, NewConnectionCallID: req.InvokeID})
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &messages.MakeCallReq{DialedNumber: "5551234"}
+	first, err := c.MakeCall(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.MakeCall(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.InvokeID != 0 || req.PeripheralID != 0 {
+		t.Errorf("MakeCall set InvokeID %d and PeripheralID %d on the caller's request", req.InvokeID, req.PeripheralID)
+	}
+	if first == second {
+		t.Errorf("retry reused InvokeID %d", first)
+	}
+}
+
+func TestValidateDTMF(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   string // error text, empty if valid
+	}{
+		{digits: "0123456789*#ABCD,"},
+		{digits: "", want: "invalid DTMF digits: empty"},
+		{digits: strings.Repeat("1", maxDTMFDigits+1), want: "invalid DTMF digits: 33 digits exceeds maximum of 32"},
+		{digits: "12x", want: "invalid DTMF digits: unexpected 'x' at position 2"},
+		{digits: "1é2", want: "invalid DTMF digits: unexpected 'é' at position 1"},
+	}
+
+	for _, tt := range tests {
+		err := validateDTMF(tt.digits)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("validateDTMF(%q) = %v, want nil", tt.digits, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidDTMFDigits) || err.Error() != tt.want {
+			t.Errorf("validateDTMF(%q) = %v, want %q", tt.digits, err, tt.want)
+		}
+	}
+}
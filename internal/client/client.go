@@ -13,16 +13,23 @@ import (
 	"time"
 )
 
-// EventHandler is called when an event message is received.
+// EventHandler is called when an event message is received. It runs on the
+// dispatch workers; with more than one worker it is called concurrently and
+// must be safe for concurrent use.
 type EventHandler func(msg protocol.Message)
 
 // Client manages the connection to a CTI server.
 type Client struct {
-	cfg       *config.Config
-	logger    *slog.Logger
-	handler   EventHandler
-	session   *Session
-	heartbeat *Heartbeat
+	cfg        *config.Config
+	logger     *slog.Logger
+	dispatcher *dispatcher
+	session    *Session
+	heartbeat  *Heartbeat
+	pending    *pendingRequests
+	backoff    *backoff
+	sides      *serverSides
+
+	configKeys configKeys
 
 	mu        sync.Mutex
 	conn      net.Conn
@@ -33,11 +40,20 @@ type Client struct {
 // New creates a new CTI client.
 func New(cfg *config.Config, logger *slog.Logger, handler EventHandler) *Client {
 	c := &Client{
-		cfg:       cfg,
-		logger:    logger,
-		handler:   handler,
-		session:   NewSession(),
-		closeChan: make(chan struct{}),
+		cfg:        cfg,
+		logger:     logger,
+		dispatcher: newDispatcher(cfg, handler, logger.With("component", "dispatch")),
+		session:    NewSession(),
+		pending:    newPendingRequests(),
+		backoff:    newBackoff(cfg.ReconnectDelay, cfg.ReconnectMaxDelay, cfg.ReconnectMaxAttempts),
+		sides:      newServerSides(cfg.Endpoints()),
+		closeChan:  make(chan struct{}),
+	}
+
+	// Reading stops while a queue is full under the block policy, so the
+	// confirmations of outstanding requests cannot arrive until it resumes
+	c.dispatcher.onBlock = func() {
+		c.pending.failAll(ErrDispatchBlocked)
 	}
 
 	// Create heartbeat manager (will be started after session opens)
@@ -51,8 +67,14 @@ func New(cfg *config.Config, logger *slog.Logger, handler EventHandler) *Client
 	return c
 }
 
-// Run connects to the CTI server and processes messages until the context is canceled.
+// Run connects to the CTI server and processes messages until the context is
+// canceled, reconnecting with backoff when the connection is lost. It returns
+// an error wrapping ErrReconnectAttemptsExhausted if the server stays
+// unreachable for ReconnectMaxAttempts consecutive attempts.
 func (c *Client) Run(ctx context.Context) error {
+	c.dispatcher.start()
+	defer c.dispatcher.stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -64,29 +86,59 @@ func (c *Client) Run(ctx context.Context) error {
 
 		if err := c.connect(ctx); err != nil {
 			c.logger.Error("connection failed", "error", err)
-			c.waitForRetry(ctx)
+			c.failover("connect failed")
+			if err := c.waitForRetry(ctx); err != nil {
+				return err
+			}
 			continue
 		}
 
 		if err := c.open(ctx); err != nil {
 			c.logger.Error("failed to open session", "error", err)
+			c.failover("open failed")
 			c.disconnect()
-			c.waitForRetry(ctx)
+			if err := c.waitForRetry(ctx); err != nil {
+				return err
+			}
 			continue
 		}
-
-		// Start heartbeat
-		heartbeatCtx, cancelHeartbeat := context.WithCancel(ctx)
-		heartbeatDone := make(chan struct{})
+		openedAt := time.Now()
+		openedSide, _ := c.sides.current()
+		c.dispatcher.resume()
+
+		// Start heartbeat and config sync for this session
+		sessionCtx, cancelSession := context.WithCancel(ctx)
+		var sessionWG sync.WaitGroup
+		sessionWG.Add(1)
 		go func() {
-			defer close(heartbeatDone)
-			c.heartbeat.Run(heartbeatCtx)
+			defer sessionWG.Done()
+			c.heartbeat.Run(sessionCtx)
 		}()
+		if c.cfg.ConfigMsgMask != 0 {
+			sessionWG.Add(1)
+			go func() {
+				defer sessionWG.Done()
+				c.syncConfig(sessionCtx)
+			}()
+		}
+		sessionWG.Add(1)
+		go func() {
+			defer sessionWG.Done()
+			<-sessionCtx.Done()
+			c.dispatcher.interrupt() // a reader blocked on a full queue would not see ctx
+		}()
+		if side, _ := c.sides.current(); side != 0 && c.cfg.FailbackInterval > 0 {
+			sessionWG.Add(1)
+			go func() {
+				defer sessionWG.Done()
+				c.watchPrimary(sessionCtx)
+			}()
+		}
 
 		// Process messages until error or context canceled
 		err := c.processMessages(ctx)
-		cancelHeartbeat()
-		<-heartbeatDone // wait for the goroutine to finish before reconnecting
+		cancelSession()
+		sessionWG.Wait() // wait for the goroutines to finish before reconnecting
 
 		if err != nil {
 			c.logger.Error("message processing error", "error", err)
@@ -94,12 +146,25 @@ func (c *Client) Run(ctx context.Context) error {
 
 		c.disconnect()
 
+		// A session that stayed up long enough was healthy; start the backoff over
+		if time.Since(openedAt) >= c.cfg.ReconnectResetAfter {
+			c.backoff.reset()
+		}
+
+		// A deliberate switch of side, by failover or failback, reconnects
+		// right away; the new side has not failed yet.
+		if side, _ := c.sides.current(); side != openedSide {
+			continue
+		}
+
 		// Check if we should retry
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			c.waitForRetry(ctx)
+			if err := c.waitForRetry(ctx); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -108,14 +173,11 @@ func (c *Client) Run(ctx context.Context) error {
 func (c *Client) connect(ctx context.Context) error {
 	c.session.SetState(StateConnecting)
 
-	addr := fmt.Sprintf("%s:%d", c.cfg.ServerHost, c.cfg.ServerPort)
-	c.logger.Info("connecting to CTI server", "address", addr)
+	side, endpoint := c.sides.current()
+	addr := endpoint.String()
+	c.logger.Info("connecting to CTI server", "side", sideName(side), "address", addr)
 
-	dialer := net.Dialer{
-		Timeout: 30 * time.Second,
-	}
-
-	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	conn, err := c.dial(ctx, addr)
 	if err != nil {
 		c.session.SetState(StateDisconnected)
 		return fmt.Errorf("failed to connect: %w", err)
@@ -127,7 +189,7 @@ func (c *Client) connect(ctx context.Context) error {
 	c.mu.Unlock()
 
 	c.session.SetState(StateConnected)
-	c.logger.Info("connected to CTI server")
+	c.logger.Info("connected to CTI server", "side", sideName(side), "address", addr, "tls", c.cfg.TLSEnabled)
 
 	return nil
 }
@@ -146,13 +208,19 @@ func (c *Client) open(ctx context.Context) error {
 		AgentStateMask:    c.cfg.AgentStateMask,    // Agent state events from config
 		ConfigMsgMask:     c.cfg.ConfigMsgMask,     // Config events from config
 		ClientID:          c.cfg.ClientID,
+		ClientPassword:    c.cfg.ClientPassword,
+		ClientSignature:   c.cfg.ClientSignature,
+		AgentExtension:    c.cfg.AgentExtension,
+		AgentID:           c.cfg.AgentID,
+		AgentInstrument:   c.cfg.AgentInstrument,
+		ApplicationPathID: c.cfg.ApplicationPathID,
 	}
 
 	if err := c.sendMessage(openReq); err != nil {
 		return fmt.Errorf("failed to send OPEN_REQ: %w", err)
 	}
 
-	c.logger.Info("sent OPEN_REQ", "invokeID", openReq.InvokeID)
+	c.logger.Info("sent OPEN_REQ", "invokeID", openReq.InvokeID, "agentID", openReq.AgentID)
 
 	// Wait for OPEN_CONF with timeout
 	deadline := time.Now().Add(30 * time.Second)
@@ -183,11 +251,8 @@ func (c *Client) open(ctx context.Context) error {
 				"agentState", protocol.AgentStateName(m.AgentState))
 			return nil
 
-		case *messages.FailureConf:
-			return fmt.Errorf("OPEN_REQ rejected with status %d", m.Status)
-
-		case *messages.FailureEvent:
-			return fmt.Errorf("failure event received with status %d", m.Status)
+		case *messages.FailureConf, *messages.FailureEvent:
+			return failureError(openReq.Type(), m)
 
 		default:
 			c.logger.Warn("unexpected message during open",
@@ -207,10 +272,18 @@ func (c *Client) processMessages(ctx context.Context) error {
 		default:
 		}
 
+		// The connection may have been dropped by heartbeat failure or failover
+		c.mu.Lock()
+		conn, reader := c.conn, c.reader
+		c.mu.Unlock()
+		if conn == nil {
+			return ErrConnectionLost
+		}
+
 		// Set a read deadline to allow periodic context checks
-		c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
 
-		msg, err := c.reader.ReadMessage()
+		msg, frame, err := reader.ReadFrame()
 		if err != nil {
 			var netErr net.Error
 			if errors.As(err, &netErr) && netErr.Timeout() {
@@ -219,15 +292,20 @@ func (c *Client) processMessages(ctx context.Context) error {
 			return err
 		}
 
-		c.handleMessage(msg)
+		c.handleMessage(msg, frame)
 	}
 }
 
-// handleMessage processes a received message.
-func (c *Client) handleMessage(msg protocol.Message) {
+// handleMessage processes a received message and the frame it was read from.
+func (c *Client) handleMessage(msg protocol.Message, frame []byte) {
 	msgType := msg.Type()
 	c.logger.Debug("received message", "type", protocol.MessageTypeName(msgType))
 
+	// Hand confirmations to the request waiting for them
+	if conf, ok := msg.(messages.Confirmation); ok && c.pending.deliver(conf.GetInvokeID(), msg, frame) {
+		return
+	}
+
 	switch m := msg.(type) {
 	case *messages.HeartbeatConf:
 		c.heartbeat.Confirm(m.InvokeID)
@@ -237,24 +315,33 @@ func (c *Client) handleMessage(msg protocol.Message) {
 		c.session.SetState(StateDisconnected)
 
 	case *messages.FailureConf:
-		c.logger.Error("received FAILURE_CONF", "status", m.Status, "invokeID", m.InvokeID)
+		c.logger.Error("received FAILURE_CONF for no pending request",
+			"invokeID", m.InvokeID, "error", failureError(0, m))
 
 	case *messages.FailureEvent:
-		c.logger.Error("received FAILURE_EVENT", "status", m.Status)
+		c.logger.Error("received FAILURE_EVENT", "error", failureError(0, m))
+
+	case *messages.ConfigBeginEvent:
+		c.configKeys.started(m)
+		c.dispatcher.dispatch(msg, frame)
+
+	case *messages.ConfigEndEvent:
+		c.configKeys.commit(m)
+		c.dispatcher.dispatch(msg, frame)
 
 	case *messages.SystemEvent:
 		c.logger.Info("received SYSTEM_EVENT",
 			"eventID", m.SystemEventID,
 			"eventName", m.EventName())
-		if c.handler != nil {
-			c.handler(msg)
+		c.dispatcher.dispatch(msg, frame)
+		if m.SystemEventID == protocol.SystemEventCTIServerOffline {
+			c.failover("CTI server offline")
+			c.disconnect()
 		}
 
 	default:
 		// Pass all other messages to the handler
-		if c.handler != nil {
-			c.handler(msg)
-		}
+		c.dispatcher.dispatch(msg, frame)
 	}
 }
 
@@ -285,6 +372,7 @@ func (c *Client) sendHeartbeat() (uint32, error) {
 // onHeartbeatFailure is called when heartbeat fails.
 func (c *Client) onHeartbeatFailure() {
 	c.logger.Error("heartbeat failure, triggering reconnect")
+	c.failover("heartbeat failure")
 	c.disconnect()
 }
 
@@ -299,6 +387,10 @@ func (c *Client) disconnect() {
 	}
 	c.reader = nil
 	c.session.Reset()
+	c.pending.failAll(ErrConnectionLost)
+
+	// Wake the reader if it is blocked on a full dispatch queue
+	c.dispatcher.interrupt()
 }
 
 // Close gracefully closes the session.
@@ -328,15 +420,6 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// waitForRetry waits before retrying a connection.
-func (c *Client) waitForRetry(ctx context.Context) {
-	c.logger.Info("waiting before retry", "delay", c.cfg.ReconnectDelay)
-	select {
-	case <-ctx.Done():
-	case <-time.After(c.cfg.ReconnectDelay):
-	}
-}
-
 // State returns the current session state.
 func (c *Client) State() SessionState {
 	return c.session.State()
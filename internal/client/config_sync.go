@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// configKeyTimeout bounds the wait for CONFIG_KEY_EVENT during config sync.
+const configKeyTimeout = 30 * time.Second
+
+// configKeys tracks the config key of the last completed config sync and the
+// key of the sync in progress. It lives in the Client, not the Session, so it
+// survives reconnects.
+type configKeys struct {
+	mu          sync.Mutex
+	last        []byte // Key of the last configuration fully received
+	pending     []byte // Key of the configuration being received
+	pendingType uint16 // ConfigType of the outstanding CONFIG_REQUEST_EVENT
+	sent        bool   // CONFIG_REQUEST_EVENT is being or has been sent
+	receiving   bool   // The CONFIG_BEGIN_EVENT answering it has arrived
+	answerType  uint16 // ConfigType of that CONFIG_BEGIN_EVENT
+}
+
+// current returns the key of the last configuration fully received.
+func (k *configKeys) current() []byte {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.last
+}
+
+// begin records the key of the configuration about to be requested and marks
+// the request as sent. This happens before sending, since the answering
+// CONFIG_BEGIN_EVENT may be read before the send returns.
+func (k *configKeys) begin(key []byte, configType uint16) {
+	k.mu.Lock()
+	k.pending = key
+	k.pendingType = configType
+	k.sent = true
+	k.receiving = false
+	k.mu.Unlock()
+}
+
+// abandon drops the pending key when the request could not be sent.
+func (k *configKeys) abandon() {
+	k.mu.Lock()
+	k.pending = nil
+	k.pendingType = 0
+	k.sent = false
+	k.receiving = false
+	k.mu.Unlock()
+}
+
+// started notes a CONFIG_BEGIN_EVENT. The first one after the request was
+// sent whose type is within the requested types starts the answer to it;
+// others are unsolicited configuration pushes.
+func (k *configKeys) started(ev *messages.ConfigBeginEvent) {
+	k.mu.Lock()
+	if k.pending != nil && k.sent && !k.receiving && ev.ConfigType&^k.pendingType == 0 {
+		k.receiving = true
+		k.answerType = ev.ConfigType
+	}
+	k.mu.Unlock()
+}
+
+// commit makes the pending key current once the configuration requested has
+// been received. CONFIG_END_EVENTs of unsolicited pushes are ignored.
+func (k *configKeys) commit(ev *messages.ConfigEndEvent) {
+	k.mu.Lock()
+	if k.receiving && ev.ConfigType == k.answerType {
+		k.last = k.pending
+		k.pending = nil
+		k.sent = false
+		k.receiving = false
+	}
+	k.mu.Unlock()
+}
+
+// RequestConfigKey returns the key identifying the server's current configuration.
+func (c *Client) RequestConfigKey(ctx context.Context) ([]byte, error) {
+	req := &messages.ConfigRequestKeyEvent{
+		InvokeID:     c.session.NextInvokeID(),
+		PeripheralID: c.peripheralID(0),
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ev, ok := resp.(*messages.ConfigKeyEvent)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to CONFIG_REQUEST_KEY_EVENT: %T", resp)
+	}
+	return ev.ConfigKey, nil
+}
+
+// ConfigKey returns the key of the last configuration fully received, or nil
+// before the first config sync completes.
+func (c *Client) ConfigKey() []byte {
+	return c.configKeys.current()
+}
+
+// syncConfig brings the configuration up to date after the session opens.
+// If the server's config key matches the last one received nothing is
+// requested; otherwise the configuration is requested, as the changes since
+// the last key when there is one. The config events themselves reach the
+// EventHandler, and the new key becomes current on the CONFIG_END_EVENT that
+// answers the request, not on those of unsolicited pushes.
+func (c *Client) syncConfig(ctx context.Context) {
+	keyCtx, cancel := context.WithTimeout(ctx, configKeyTimeout)
+	key, err := c.RequestConfigKey(keyCtx)
+	cancel()
+	if err != nil {
+		c.logger.Warn("config key request failed", "error", err)
+		return
+	}
+
+	last := c.configKeys.current()
+	if last != nil && bytes.Equal(key, last) {
+		c.logger.Info("configuration unchanged since last sync")
+		return
+	}
+
+	req := &messages.ConfigRequestEvent{
+		PeripheralID: c.peripheralID(0),
+		ConfigType:   configRequestType(c.cfg.ConfigMsgMask),
+		ConfigKey:    last,
+	}
+
+	c.configKeys.begin(key, req.ConfigType)
+	if err := c.sendMessage(req); err != nil {
+		c.configKeys.abandon()
+		c.logger.Warn("failed to send CONFIG_REQUEST_EVENT", "error", err)
+		return
+	}
+
+	c.logger.Info("requested configuration", "delta", last != nil)
+}
+
+// configRequestType returns the 16-bit ConfigType of CONFIG_REQUEST_EVENT for
+// the 32-bit OPEN_REQ config mask. ConfigMaskAll requests every type; other
+// masks are limited to 16 bits by Config.Validate.
+func configRequestType(mask uint32) uint16 {
+	if mask == protocol.ConfigMaskAll {
+		return protocol.ConfigTypeAll
+	}
+	return uint16(mask)
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"testing"
+	"time"
+)
+
+// The answering CONFIG_BEGIN_EVENT can be read before the send of
+// CONFIG_REQUEST_EVENT returns; it must still start the answer.
+func TestConfigKeysBeginBeforeSendReturns(t *testing.T) {
+	var k configKeys
+	key := []byte{1, 2, 3, 4}
+
+	k.begin(key, protocol.ConfigTypeAll)
+	k.started(&messages.ConfigBeginEvent{ConfigType: protocol.ConfigTypeAll})
+	k.commit(&messages.ConfigEndEvent{ConfigType: protocol.ConfigTypeAll})
+
+	if !bytes.Equal(k.current(), key) {
+		t.Errorf("current key = %v, want %v", k.current(), key)
+	}
+}
+
+func TestConfigKeysAbandon(t *testing.T) {
+	var k configKeys
+
+	k.begin([]byte{1}, protocol.ConfigTypeAll)
+	k.abandon()
+	k.started(&messages.ConfigBeginEvent{ConfigType: protocol.ConfigTypeAll})
+	k.commit(&messages.ConfigEndEvent{ConfigType: protocol.ConfigTypeAll})
+
+	if k.current() != nil {
+		t.Errorf("current key = %v after an abandoned request, want nil", k.current())
+	}
+}
+
+func TestSyncConfigCommitsKey(t *testing.T) {
+	c, server := newTestClient(t, func(protocol.Message) {})
+	key := []byte{1, 2, 3, 4}
+
+	go func() {
+		req, ok := serverRead(t, server).(*messages.ConfigRequestKeyEvent)
+		if !ok {
+			return
+		}
+		serverWrite(t, server, &messages.ConfigKeyEvent{InvokeID: req.InvokeID, ConfigKey: key})
+
+		cfgReq, ok := serverRead(t, server).(*messages.ConfigRequestEvent)
+		if !ok {
+			return
+		}
+		serverWrite(t, server, &messages.ConfigBeginEvent{ConfigType: cfgReq.ConfigType})
+		serverWrite(t, server, &messages.ConfigEndEvent{ConfigType: cfgReq.ConfigType})
+	}()
+
+	c.syncConfig(context.Background())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !bytes.Equal(c.ConfigKey(), key) {
+		if time.Now().After(deadline) {
+			t.Fatalf("ConfigKey = %v, want %v", c.ConfigKey(), key)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"fmt"
+)
+
+// QueryDeviceInfo returns the peripheral type, call control capabilities and
+// lines of the given instrument. Results are cached per instrument for the
+// life of the session, so only the first call for a device goes to the server.
+// Each call returns its own copy, which the caller may modify.
+func (c *Client) QueryDeviceInfo(ctx context.Context, instrument string) (*messages.QueryDeviceInfoConf, error) {
+	if info, ok := c.session.DeviceInfo(instrument); ok {
+		return info, nil
+	}
+
+	req := &messages.QueryDeviceInfoReq{
+		InvokeID:        c.session.NextInvokeID(),
+		PeripheralID:    c.peripheralID(0),
+		AgentInstrument: instrument,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.QueryDeviceInfoConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to QUERY_DEVICE_INFO_REQ: %T", resp)
+	}
+
+	c.session.SetDeviceInfo(instrument, conf)
+	return conf, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"testing"
+	"time"
+)
+
+func TestQueryDeviceInfoReturnsCopies(t *testing.T) {
+	c, server := newTestClient(t, nil)
+	go func() {
+		req, ok := serverRead(t, server).(*messages.QueryDeviceInfoReq)
+		if !ok {
+			return
+		}
+		serverWrite(t, server, &messages.QueryDeviceInfoConf{
+			InvokeID: req.InvokeID,
+			NumLines: 1,
+			Lines:    []messages.Line{{LineHandle: 0, LineType: 1}},
+		})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first, err := c.QueryDeviceInfo(ctx, "4001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.NumLines = 0
+	first.Lines[0].LineType = 9
+
+	// Answered from the cache; the server only replies once
+	second, err := c.QueryDeviceInfo(ctx, "4001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.NumLines != 1 || second.Lines[0].LineType != 1 {
+		t.Errorf("cached device info changed by the caller: %+v", second)
+	}
+}
@@ -0,0 +1,456 @@
+package client
+
+import (
+	"ctiservice/internal/config"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrDispatchBlocked is returned to requests made while reading is stopped
+// by a full dispatch queue under the block policy. Their confirmations could
+// not be read until the EventHandler catches up, which never happens if the
+// handler itself is waiting for one.
+var ErrDispatchBlocked = errors.New("dispatch queue full, reading blocked")
+
+// dispatchStopTimeout bounds the wait in stop for the workers to deliver the
+// queued events, so a handler that never returns cannot hang shutdown.
+const dispatchStopTimeout = 10 * time.Second
+
+// DispatchStats reports the state of the event dispatch queues.
+type DispatchStats struct {
+	Depth    int    // Events waiting for the handler, in memory and on disk
+	Capacity int    // In-memory capacity across all workers
+	Dropped  uint64 // Events discarded by drop-oldest or that could not be spilled
+	Spilled  uint64 // Events written to disk by the spill policy
+}
+
+// dispatcher delivers events to the EventHandler on worker goroutines so a
+// slow handler does not stall reading from the CTI server. Events of one call
+// always go to the same worker, which keeps them in order; all other events go
+// to the first worker. With several workers the handler is called
+// concurrently.
+type dispatcher struct {
+	handler EventHandler
+	logger  *slog.Logger
+	queues  []*dispatchQueue
+	wg      sync.WaitGroup
+
+	stopTimeout time.Duration
+
+	// onBlock is called when reading stops on a full queue under the block
+	// policy, to fail the requests whose confirmations can no longer be read
+	onBlock func()
+	blocked atomic.Bool
+
+	dropped atomic.Uint64
+	spilled atomic.Uint64
+}
+
+// dispatchItem is a queued event and the frame it was read from.
+type dispatchItem struct {
+	msg   protocol.Message
+	frame []byte
+}
+
+// newDispatcher creates a dispatcher from the Dispatch* settings. Workers are
+// started by start.
+func newDispatcher(cfg *config.Config, handler EventHandler, logger *slog.Logger) *dispatcher {
+	d := &dispatcher{
+		handler:     handler,
+		logger:      logger,
+		stopTimeout: dispatchStopTimeout,
+	}
+	for i := 0; i < cfg.DispatchWorkers; i++ {
+		q := &dispatchQueue{
+			d:        d,
+			worker:   i,
+			capacity: cfg.DispatchQueueSize,
+			overflow: cfg.DispatchOverflow,
+			spill:    spillFile{dir: cfg.DispatchSpillDir, registry: messages.NewRegistry()},
+			closed:   true,
+		}
+		q.cond = sync.NewCond(&q.mu)
+		d.queues = append(d.queues, q)
+	}
+	return d
+}
+
+// start starts one goroutine per worker queue.
+func (d *dispatcher) start() {
+	for _, q := range d.queues {
+		q.mu.Lock()
+		q.closed = false
+		q.mu.Unlock()
+
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for {
+				item, ok := q.pop()
+				if !ok {
+					return
+				}
+				d.handler(item.msg)
+			}
+		}()
+	}
+}
+
+// stop stops accepting events and waits for the workers to deliver the events
+// already queued, including any spilled to disk, then removes the spill files.
+// If the handler has not caught up within stopTimeout the remaining events are
+// left to the workers and stop returns.
+func (d *dispatcher) stop() {
+	for _, q := range d.queues {
+		q.mu.Lock()
+		q.closed = true
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(d.stopTimeout):
+		d.logger.Warn("event handler did not return, stopping without delivering queued events",
+			"timeout", d.stopTimeout)
+	}
+
+	for _, q := range d.queues {
+		q.mu.Lock()
+		q.spill.remove()
+		q.mu.Unlock()
+	}
+}
+
+// dispatch queues msg for the EventHandler. frame is the message as read
+// from the connection, which the spill policy writes to disk.
+func (d *dispatcher) dispatch(msg protocol.Message, frame []byte) {
+	if d.handler == nil {
+		return
+	}
+
+	q := d.queues[0]
+	if callID, ok := callIDOf(msg); ok {
+		q = d.queues[callID%uint32(len(d.queues))]
+	}
+	q.push(dispatchItem{msg: msg, frame: frame})
+}
+
+// interrupt makes a push blocked on a full queue under the block policy give
+// up and drop its event, and makes further pushes to a full queue do the same,
+// so the reader can return when the session is torn down. resume undoes it for
+// the next session.
+func (d *dispatcher) interrupt() {
+	for _, q := range d.queues {
+		q.mu.Lock()
+		q.interrupted = true
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}
+}
+
+// resume lets pushes block on a full queue again after interrupt.
+func (d *dispatcher) resume() {
+	for _, q := range d.queues {
+		q.mu.Lock()
+		q.interrupted = false
+		q.mu.Unlock()
+	}
+}
+
+// isBlocked reports whether reading is stopped on a full queue.
+func (d *dispatcher) isBlocked() bool {
+	return d.blocked.Load()
+}
+
+// stats returns the current depth and overflow counters.
+func (d *dispatcher) stats() DispatchStats {
+	var s DispatchStats
+	for _, q := range d.queues {
+		q.mu.Lock()
+		s.Depth += len(q.items) + q.spill.count
+		s.Capacity += q.capacity
+		q.mu.Unlock()
+	}
+	s.Dropped = d.dropped.Load()
+	s.Spilled = d.spilled.Load()
+	return s
+}
+
+// DispatchStats returns the depth and overflow counters of the event dispatch
+// queues.
+func (c *Client) DispatchStats() DispatchStats {
+	return c.dispatcher.stats()
+}
+
+// dispatchQueue is the bounded FIFO of one dispatch worker. With the spill
+// policy, events that do not fit are appended to a spill file and read back
+// once the in-memory events have been handled, so order is preserved.
+type dispatchQueue struct {
+	d        *dispatcher
+	worker   int
+	capacity int
+	overflow string
+
+	mu          sync.Mutex
+	cond        *sync.Cond // Signaled when events are pushed or popped, or on stop or interrupt
+	items       []dispatchItem
+	spill       spillFile
+	closed      bool
+	interrupted bool // The session is being torn down; a full queue drops instead of blocking
+	overflowing bool // The current overflow has been logged
+}
+
+// push adds item to the queue, applying the overflow policy when it is full.
+func (q *dispatchQueue) push(item dispatchItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		q.d.dropped.Add(1)
+		return
+	}
+
+	switch q.overflow {
+	case config.OverflowBlock:
+		if len(q.items) >= q.capacity && !q.closed && !q.interrupted {
+			q.noteOverflow()
+			q.d.blocked.Store(true)
+			if q.d.onBlock != nil {
+				q.d.onBlock()
+			}
+			for len(q.items) >= q.capacity && !q.closed && !q.interrupted {
+				q.cond.Wait()
+			}
+			q.d.blocked.Store(false)
+		}
+		// Still full only if interrupted
+		if q.closed || len(q.items) >= q.capacity {
+			q.d.dropped.Add(1)
+			return
+		}
+
+	case config.OverflowDropOldest:
+		if len(q.items) >= q.capacity {
+			q.noteOverflow()
+			q.items[0] = dispatchItem{}
+			q.items = q.items[1:]
+			q.d.dropped.Add(1)
+		}
+
+	case config.OverflowSpill:
+		if len(q.items) >= q.capacity || q.spill.count > 0 {
+			q.noteOverflow()
+			if err := q.spill.write(item.frame); err != nil {
+				q.d.logger.Error("failed to spill event", "worker", q.worker, "error", err)
+				q.d.dropped.Add(1)
+				return
+			}
+			q.d.spilled.Add(1)
+			q.cond.Broadcast()
+			return
+		}
+	}
+
+	q.items = append(q.items, item)
+	q.cond.Broadcast()
+}
+
+// pop removes the next event, waiting until one is available. It returns
+// false once the queue is stopped and empty.
+func (q *dispatchQueue) pop() (dispatchItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if len(q.items) > 0 {
+			item := q.items[0]
+			q.items[0] = dispatchItem{}
+			q.items = q.items[1:]
+			q.cond.Broadcast() // wake a push blocked on a full queue
+			return item, true
+		}
+
+		if q.spill.count > 0 {
+			item, err := q.spill.read()
+			if err == nil {
+				return item, true
+			}
+			q.d.logger.Error("failed to read spilled events, discarding them",
+				"worker", q.worker, "events", q.spill.count, "error", err)
+			q.d.dropped.Add(uint64(q.spill.count))
+			q.spill.reset()
+			continue
+		}
+
+		if q.overflowing {
+			q.overflowing = false
+			q.d.logger.Info("dispatch queue drained", "worker", q.worker,
+				"dropped", q.d.dropped.Load(), "spilled", q.d.spilled.Load())
+		}
+
+		if q.closed {
+			return dispatchItem{}, false
+		}
+		q.cond.Wait()
+	}
+}
+
+// noteOverflow logs the first overflow until the queue drains again.
+func (q *dispatchQueue) noteOverflow() {
+	if q.overflowing {
+		return
+	}
+	q.overflowing = true
+	q.d.logger.Warn("dispatch queue full", "worker", q.worker,
+		"capacity", q.capacity, "policy", q.overflow)
+}
+
+// spillFile is an on-disk FIFO of message frames exactly as they were read
+// from the connection. The file is created on first use and truncated
+// whenever it has been read to the end.
+type spillFile struct {
+	dir      string
+	registry *messages.Registry
+
+	file     *os.File
+	readOff  int64
+	writeOff int64
+	count    int // Messages written and not yet read
+}
+
+// write appends a frame to the file.
+func (s *spillFile) write(frame []byte) error {
+	if len(frame) < protocol.HeaderSize {
+		return fmt.Errorf("no frame to spill")
+	}
+
+	if s.file == nil {
+		f, err := os.CreateTemp(s.dir, "ctiservice-spill-*")
+		if err != nil {
+			return fmt.Errorf("failed to create spill file: %w", err)
+		}
+		s.file = f
+	}
+
+	if _, err := s.file.WriteAt(frame, s.writeOff); err != nil {
+		return fmt.Errorf("failed to write spill file: %w", err)
+	}
+	s.writeOff += int64(len(frame))
+	s.count++
+	return nil
+}
+
+// read removes and returns the oldest message in the file, parsed from its
+// frame.
+func (s *spillFile) read() (dispatchItem, error) {
+	r := io.NewSectionReader(s.file, s.readOff, s.writeOff-s.readOff)
+
+	header, err := protocol.ReadHeader(r)
+	if err != nil {
+		return dispatchItem{}, fmt.Errorf("failed to read spilled message header: %w", err)
+	}
+	frame := make([]byte, protocol.HeaderSize+int(header.MessageLength))
+	copy(frame, header.Bytes())
+	body := frame[protocol.HeaderSize:]
+	if _, err := io.ReadFull(r, body); err != nil {
+		return dispatchItem{}, fmt.Errorf("failed to read spilled message body: %w", err)
+	}
+
+	s.readOff += int64(len(frame))
+	s.count--
+	if s.count == 0 {
+		s.reset()
+	}
+
+	msg, err := s.registry.Parse(header.MessageType, body)
+	if err != nil {
+		return dispatchItem{}, err
+	}
+	return dispatchItem{msg: msg, frame: frame}, nil
+}
+
+// reset empties the file.
+func (s *spillFile) reset() {
+	s.readOff, s.writeOff, s.count = 0, 0, 0
+	if s.file != nil {
+		s.file.Truncate(0)
+	}
+}
+
+// remove closes and deletes the file.
+func (s *spillFile) remove() {
+	s.readOff, s.writeOff, s.count = 0, 0, 0
+	if s.file != nil {
+		s.file.Close()
+		os.Remove(s.file.Name())
+		s.file = nil
+	}
+}
+
+// callIDOf returns the call ID of call events, which selects the worker they
+// are dispatched to. Conference and transfer events use the primary call,
+// which is the call that survives them.
+func callIDOf(msg protocol.Message) (uint32, bool) {
+	switch m := msg.(type) {
+	case *messages.BeginCallEvent:
+		return m.ConnectionCallID, true
+	case *messages.EndCallEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallDeliveredEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallEstablishedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallHeldEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallRetrievedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallClearedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallConnectionClearedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallOriginatedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallFailedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallConferencedEvent:
+		return m.PrimaryCallID, true
+	case *messages.CallTransferredEvent:
+		return m.PrimaryCallID, true
+	case *messages.CallDivertedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallQueuedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallDequeuedEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallDataUpdateEvent:
+		return m.ConnectionCallID, true
+	case *messages.CallServiceInitiatedEvent:
+		return m.ConnectionCallID, true
+	case *messages.AgentPreCallEvent:
+		return m.ConnectionCallID, true
+	case *messages.AgentPreCallAbortEvent:
+		return m.ConnectionCallID, true
+	case *messages.SupervisorAssistEvent:
+		return m.ConnectionCallID, true
+	case *messages.RTPStartedEvent:
+		return m.ConnectionCallID, true
+	case *messages.RTPStoppedEvent:
+		return m.ConnectionCallID, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,436 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/config"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testEvent returns a call event for callID carrying seq, and its frame.
+func testEvent(t *testing.T, callID, seq uint32) (protocol.Message, []byte) {
+	t.Helper()
+	msg := &messages.CallDivertedEvent{ConnectionCallID: callID, ServiceNumber: seq, ConnectionDeviceID: "4001"}
+	frame, err := protocol.EncodeMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg, frame
+}
+
+// gatedHandler records the events it handles. The first event blocks the
+// worker until release is called, so tests can fill the queue behind it.
+type gatedHandler struct {
+	started chan struct{}
+	gate    chan struct{}
+	once    sync.Once
+
+	mu     sync.Mutex
+	events []*messages.CallDivertedEvent
+	done   chan struct{}
+	want   int
+}
+
+func newGatedHandler(want int) *gatedHandler {
+	return &gatedHandler{
+		started: make(chan struct{}),
+		gate:    make(chan struct{}),
+		done:    make(chan struct{}),
+		want:    want,
+	}
+}
+
+func (h *gatedHandler) handle(msg protocol.Message) {
+	h.once.Do(func() {
+		close(h.started)
+		<-h.gate
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, msg.(*messages.CallDivertedEvent))
+	if len(h.events) == h.want {
+		close(h.done)
+	}
+}
+
+func (h *gatedHandler) release() {
+	close(h.gate)
+}
+
+// wait waits until want events were handled and returns their sequence numbers.
+func (h *gatedHandler) wait(t *testing.T) []uint32 {
+	t.Helper()
+	select {
+	case <-h.done:
+	case <-time.After(5 * time.Second):
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		t.Fatalf("handled %d events, want %d", len(h.events), h.want)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	seqs := make([]uint32, len(h.events))
+	for i, ev := range h.events {
+		seqs[i] = ev.ServiceNumber
+	}
+	return seqs
+}
+
+func newTestDispatcher(t *testing.T, workers, queueSize int, overflow string, handler EventHandler) *dispatcher {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.DispatchWorkers = workers
+	cfg.DispatchQueueSize = queueSize
+	cfg.DispatchOverflow = overflow
+	cfg.DispatchSpillDir = t.TempDir()
+
+	d := newDispatcher(cfg, handler, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	d.start()
+	t.Cleanup(d.stop)
+	return d
+}
+
+func equalSeqs(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDispatchDropOldest(t *testing.T) {
+	h := newGatedHandler(3)
+	d := newTestDispatcher(t, 1, 2, config.OverflowDropOldest, h.handle)
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+	for seq := uint32(2); seq <= 5; seq++ {
+		d.dispatch(testEvent(t, 1, seq))
+	}
+	h.release()
+
+	// 1 was being handled, 2 and 3 were dropped for 4 and 5
+	if got, want := h.wait(t), []uint32{1, 4, 5}; !equalSeqs(got, want) {
+		t.Errorf("handled %v, want %v", got, want)
+	}
+	if s := d.stats(); s.Dropped != 2 || s.Spilled != 0 {
+		t.Errorf("stats = %+v, want 2 dropped and none spilled", s)
+	}
+}
+
+func TestDispatchSpill(t *testing.T) {
+	const n = 20
+	h := newGatedHandler(n)
+	d := newTestDispatcher(t, 1, 2, config.OverflowSpill, h.handle)
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+	for seq := uint32(2); seq <= n; seq++ {
+		d.dispatch(testEvent(t, 1, seq))
+	}
+
+	s := d.stats()
+	if s.Spilled != n-3 || s.Dropped != 0 {
+		t.Errorf("stats = %+v, want %d spilled and none dropped", s, n-3)
+	}
+	if s.Depth != n-1 {
+		t.Errorf("depth = %d, want %d", s.Depth, n-1)
+	}
+	h.release()
+
+	got := h.wait(t)
+	for i, seq := range got {
+		if seq != uint32(i+1) {
+			t.Fatalf("handled %v, want 1..%d in order", got, n)
+		}
+	}
+	if s := d.stats(); s.Depth != 0 {
+		t.Errorf("depth after draining = %d, want 0", s.Depth)
+	}
+}
+
+// A spilled event is handed to the handler as parsed from the frame it was
+// read from, not from a re-encoding of the message.
+func TestDispatchSpillKeepsFrame(t *testing.T) {
+	h := newGatedHandler(3)
+	d := newTestDispatcher(t, 1, 1, config.OverflowSpill, h.handle)
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+	d.dispatch(testEvent(t, 1, 2))
+
+	_, frame := testEvent(t, 1, 3)
+	d.dispatch(&messages.CallDivertedEvent{ConnectionCallID: 1, ServiceNumber: 99}, frame)
+	if s := d.stats(); s.Spilled != 1 {
+		t.Fatalf("stats = %+v, want 1 spilled", s)
+	}
+	h.release()
+
+	if got, want := h.wait(t), []uint32{1, 2, 3}; !equalSeqs(got, want) {
+		t.Errorf("handled %v, want %v", got, want)
+	}
+}
+
+func TestDispatchBlock(t *testing.T) {
+	h := newGatedHandler(4)
+	d := newTestDispatcher(t, 1, 2, config.OverflowBlock, h.handle)
+	blocked := make(chan struct{})
+	d.onBlock = func() { close(blocked) }
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+	d.dispatch(testEvent(t, 1, 2))
+	d.dispatch(testEvent(t, 1, 3))
+
+	pushed := make(chan struct{})
+	go func() {
+		d.dispatch(testEvent(t, 1, 4))
+		close(pushed)
+	}()
+
+	select {
+	case <-blocked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("push to a full queue did not block")
+	}
+	if !d.isBlocked() {
+		t.Error("isBlocked = false while a push waits")
+	}
+	select {
+	case <-pushed:
+		t.Fatal("push to a full queue returned before the handler caught up")
+	default:
+	}
+
+	h.release()
+	<-pushed
+	if got, want := h.wait(t), []uint32{1, 2, 3, 4}; !equalSeqs(got, want) {
+		t.Errorf("handled %v, want %v", got, want)
+	}
+	if d.isBlocked() {
+		t.Error("isBlocked = true after the queue drained")
+	}
+	if s := d.stats(); s.Dropped != 0 || s.Spilled != 0 {
+		t.Errorf("stats = %+v, want nothing dropped or spilled", s)
+	}
+}
+
+// Interrupting the dispatcher wakes a push blocked on a full queue, which
+// drops its event, and resuming it makes pushes block again.
+func TestDispatchBlockInterrupt(t *testing.T) {
+	h := newGatedHandler(3)
+	d := newTestDispatcher(t, 1, 1, config.OverflowBlock, h.handle)
+	blocked := make(chan struct{}, 1)
+	d.onBlock = func() { blocked <- struct{}{} }
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+	d.dispatch(testEvent(t, 1, 2))
+
+	pushed := make(chan struct{})
+	go func() {
+		d.dispatch(testEvent(t, 1, 3))
+		close(pushed)
+	}()
+	<-blocked
+
+	d.interrupt()
+	select {
+	case <-pushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("interrupt did not wake the blocked push")
+	}
+	if s := d.stats(); s.Dropped != 1 {
+		t.Errorf("stats = %+v, want 1 dropped", s)
+	}
+
+	d.resume()
+	pushed = make(chan struct{})
+	go func() {
+		d.dispatch(testEvent(t, 1, 4))
+		close(pushed)
+	}()
+	<-blocked
+
+	h.release()
+	<-pushed
+	if got, want := h.wait(t), []uint32{1, 2, 4}; !equalSeqs(got, want) {
+		t.Errorf("handled %v, want %v", got, want)
+	}
+}
+
+// Tearing the session down wakes a reader blocked on a full queue.
+func TestDisconnectWakesBlockedReader(t *testing.T) {
+	h := newGatedHandler(2)
+	c, server := newTestClient(t, h.handle)
+	defer h.release()
+
+	go func() {
+		for seq := uint32(1); seq <= uint32(c.cfg.DispatchQueueSize)+2; seq++ {
+			_, frame := testEvent(t, 1, seq)
+			if _, err := server.Write(frame); err != nil {
+				return // the connection was closed
+			}
+		}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !c.dispatcher.isBlocked() {
+		if time.Now().After(deadline) {
+			t.Fatal("reading did not block on the full queue")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	c.disconnect()
+	for c.dispatcher.isBlocked() {
+		if time.Now().After(deadline) {
+			t.Fatal("disconnect did not wake the blocked reader")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if s := c.DispatchStats(); s.Dropped != 1 {
+		t.Errorf("stats = %+v, want 1 dropped", s)
+	}
+}
+
+// stop gives up on a handler that does not return.
+func TestDispatchStopTimeout(t *testing.T) {
+	h := newGatedHandler(1)
+	d := newDispatcher(config.DefaultConfig(), h.handle, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	d.stopTimeout = 10 * time.Millisecond
+	d.start()
+	defer h.release()
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+
+	stopped := make(chan struct{})
+	go func() {
+		d.stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop waited for a handler that does not return")
+	}
+}
+
+// A handler that makes a request while reading is blocked on its own full
+// queue gets ErrDispatchBlocked instead of waiting forever.
+func TestDispatchBlockFailsRequests(t *testing.T) {
+	errs := make(chan error, 1)
+	var c *Client
+	var once sync.Once
+	c, server := newTestClient(t, func(msg protocol.Message) {
+		once.Do(func() {
+			// Wait until reading stops on the full queue
+			for !c.dispatcher.isBlocked() {
+				time.Sleep(time.Millisecond)
+			}
+			_, err := c.Do(context.Background(), &messages.AnswerCallReq{InvokeID: c.NextInvokeID()})
+			errs <- err
+		})
+	})
+
+	go func() {
+		for seq := uint32(1); seq <= uint32(c.cfg.DispatchQueueSize)+2; seq++ {
+			_, frame := testEvent(t, 1, seq)
+			if _, err := server.Write(frame); err != nil {
+				return // the test has ended
+			}
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrDispatchBlocked) {
+			t.Fatalf("request from a blocked handler: %v, want ErrDispatchBlocked", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request from a blocked handler deadlocked")
+	}
+}
+
+func TestDispatchPerCallOrdering(t *testing.T) {
+	const (
+		calls   = 16
+		perCall = 50
+	)
+
+	var mu sync.Mutex
+	seqs := make(map[uint32][]uint32)
+	var wg sync.WaitGroup
+	wg.Add(calls * perCall)
+	d := newTestDispatcher(t, 4, 8, config.OverflowBlock, func(msg protocol.Message) {
+		ev := msg.(*messages.CallDivertedEvent)
+		mu.Lock()
+		seqs[ev.ConnectionCallID] = append(seqs[ev.ConnectionCallID], ev.ServiceNumber)
+		mu.Unlock()
+		wg.Done()
+	})
+
+	// Interleave the events of all calls, as the server would
+	for seq := uint32(1); seq <= perCall; seq++ {
+		for call := uint32(1); call <= calls; call++ {
+			d.dispatch(testEvent(t, call, seq))
+		}
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for call, got := range seqs {
+		for i, seq := range got {
+			if seq != uint32(i+1) {
+				t.Fatalf("call %d events handled as %v, want 1..%d in order", call, got, perCall)
+			}
+		}
+	}
+}
+
+func TestDispatchStopDeliversQueued(t *testing.T) {
+	const n = 10
+	h := newGatedHandler(n)
+	cfg := config.DefaultConfig()
+	cfg.DispatchQueueSize = 2
+	cfg.DispatchOverflow = config.OverflowSpill
+	cfg.DispatchSpillDir = t.TempDir()
+	d := newDispatcher(cfg, h.handle, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	d.start()
+
+	d.dispatch(testEvent(t, 1, 1))
+	<-h.started
+	for seq := uint32(2); seq <= n; seq++ {
+		d.dispatch(testEvent(t, 1, seq))
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		d.stop()
+		close(stopped)
+	}()
+	h.release()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop did not return")
+	}
+	if got := h.wait(t); len(got) != n {
+		t.Errorf("handled %d events before stop returned, want %d", len(got), n)
+	}
+}
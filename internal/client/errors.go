@@ -0,0 +1,117 @@
+package client
+
+import (
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for FAILURE_CONF and FAILURE_EVENT status codes. A *CTIError
+// matches the sentinel for its status with errors.Is.
+var (
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrInvalidState        = errors.New("invalid state")
+	ErrInvalidSession      = errors.New("invalid session")
+	ErrInvalidService      = errors.New("invalid service")
+	ErrInvalidCallID       = errors.New("invalid call ID")
+	ErrInvalidDeviceID     = errors.New("invalid device ID")
+	ErrResourceBusy        = errors.New("resource busy")
+	ErrResourceUnavailable = errors.New("resource unavailable")
+	ErrProtocolError       = errors.New("protocol error")
+	ErrInternalError       = errors.New("internal error")
+
+	// ErrControlFailure is matched by every CONTROL_FAILURE_CONF rejection.
+	ErrControlFailure = errors.New("control failure")
+
+	// ErrServiceNotGranted is returned, without contacting the server, when a
+	// request needs a service that was not granted in OPEN_CONF. It matches
+	// ErrInvalidService like the server's own rejection would.
+	ErrServiceNotGranted = fmt.Errorf("service not granted: %w", ErrInvalidService)
+)
+
+// statusErrors maps failure status codes to their sentinel errors.
+var statusErrors = map[uint32]error{
+	protocol.StatusInvalidRequest:      ErrInvalidRequest,
+	protocol.StatusInvalidState:        ErrInvalidState,
+	protocol.StatusInvalidSession:      ErrInvalidSession,
+	protocol.StatusInvalidService:      ErrInvalidService,
+	protocol.StatusInvalidCallID:       ErrInvalidCallID,
+	protocol.StatusInvalidDeviceID:     ErrInvalidDeviceID,
+	protocol.StatusResourceBusy:        ErrResourceBusy,
+	protocol.StatusResourceUnavailable: ErrResourceUnavailable,
+	protocol.StatusProtocolError:       ErrProtocolError,
+	protocol.StatusInternalError:       ErrInternalError,
+}
+
+// CTIError is a failure reported by the CTI server in FAILURE_CONF,
+// CONTROL_FAILURE_CONF or FAILURE_EVENT.
+type CTIError struct {
+	MessageType         uint32 // Message type of the failed request, 0 for FAILURE_EVENT outside a request
+	Response            uint32 // FAILURE_CONF, CONTROL_FAILURE_CONF or FAILURE_EVENT
+	InvokeID            uint32 // InvokeID of the failed request (confirmations only)
+	Status              uint32 // Status code, or CONTROL_FAILURE_CONF failure code
+	PeripheralErrorCode uint32 // Peripheral error code (CONTROL_FAILURE_CONF only)
+}
+
+// StatusName returns a human-readable name for the status code.
+func (e *CTIError) StatusName() string {
+	if e.Response == protocol.MsgTypeControlFailureConf {
+		return protocol.ControlFailureName(uint16(e.Status))
+	}
+	return protocol.StatusName(e.Status)
+}
+
+// Error implements the error interface.
+func (e *CTIError) Error() string {
+	switch {
+	case e.Response == protocol.MsgTypeControlFailureConf:
+		return fmt.Sprintf("%s rejected with CONTROL_FAILURE_CONF: failure %s (%d), peripheral error %d",
+			protocol.MessageTypeName(e.MessageType), e.StatusName(), e.Status, e.PeripheralErrorCode)
+	case e.MessageType == 0:
+		return fmt.Sprintf("%s: status %s (%d)",
+			protocol.MessageTypeName(e.Response), e.StatusName(), e.Status)
+	default:
+		return fmt.Sprintf("%s rejected with %s: status %s (%d)",
+			protocol.MessageTypeName(e.MessageType), protocol.MessageTypeName(e.Response),
+			e.StatusName(), e.Status)
+	}
+}
+
+// Unwrap returns the sentinel error for the status, so errors.Is(err,
+// ErrInvalidState) and similar checks work on a *CTIError.
+func (e *CTIError) Unwrap() error {
+	if e.Response == protocol.MsgTypeControlFailureConf {
+		return ErrControlFailure
+	}
+	return statusErrors[e.Status]
+}
+
+// failureError converts a failure message answering or interrupting a
+// request of the given type into a *CTIError. Returns nil for any other message.
+func failureError(requestType uint32, msg protocol.Message) error {
+	switch m := msg.(type) {
+	case *messages.FailureConf:
+		return &CTIError{
+			MessageType: requestType,
+			Response:    m.Type(),
+			InvokeID:    m.InvokeID,
+			Status:      m.Status,
+		}
+	case *messages.ControlFailureConf:
+		return &CTIError{
+			MessageType:         requestType,
+			Response:            m.Type(),
+			InvokeID:            m.InvokeID,
+			Status:              uint32(m.FailureCode),
+			PeripheralErrorCode: m.PeripheralErrorCode,
+		}
+	case *messages.FailureEvent:
+		return &CTIError{
+			MessageType: requestType,
+			Response:    m.Type(),
+			Status:      m.Status,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/config"
+	"sync"
+	"time"
+)
+
+// failbackDialTimeout bounds each probe of the primary side.
+const failbackDialTimeout = 5 * time.Second
+
+// serverSides tracks the duplex CTI server sides and which one is active.
+// Sides are named A, B, ... in configuration order; side A is the primary.
+type serverSides struct {
+	mu        sync.Mutex
+	endpoints []config.ServerEndpoint
+	active    int
+}
+
+// newServerSides creates the side tracker with the primary active.
+func newServerSides(endpoints []config.ServerEndpoint) *serverSides {
+	return &serverSides{endpoints: endpoints}
+}
+
+// current returns the active side's index and endpoint.
+func (s *serverSides) current() (int, config.ServerEndpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active, s.endpoints[s.active]
+}
+
+// rotate makes the next side active and returns its index.
+func (s *serverSides) rotate() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = (s.active + 1) % len(s.endpoints)
+	return s.active
+}
+
+// setActive makes the given side active.
+func (s *serverSides) setActive(side int) {
+	s.mu.Lock()
+	s.active = side
+	s.mu.Unlock()
+}
+
+// sideName returns the duplex side name for a side index.
+func sideName(side int) string {
+	return string(rune('A' + side))
+}
+
+// ActiveSide returns the name and address of the CTI server side the client
+// is connected to, or will connect to next.
+func (c *Client) ActiveSide() (string, string) {
+	side, endpoint := c.sides.current()
+	return sideName(side), endpoint.String()
+}
+
+// failover switches to the peer side for the next connection attempt.
+// It is a no-op when only one server is configured.
+func (c *Client) failover(reason string) {
+	if len(c.sides.endpoints) < 2 {
+		return
+	}
+	from, _ := c.sides.current()
+	to := c.sides.rotate()
+	_, endpoint := c.sides.current()
+	c.logger.Warn("failing over to peer CTI server side",
+		"reason", reason,
+		"from", sideName(from),
+		"to", sideName(to),
+		"address", endpoint.String())
+}
+
+// watchPrimary probes the primary side every FailbackInterval while the
+// session is on another side. Probes use the same transport as the session,
+// including the TLS handshake when enabled. Once the primary accepts
+// connections again, it makes the primary active and drops the current
+// connection so that the client reconnects to it without backoff.
+func (c *Client) watchPrimary(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.FailbackInterval)
+	defer ticker.Stop()
+
+	primary := c.sides.endpoints[0].String()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		probeCtx, cancel := context.WithTimeout(ctx, failbackDialTimeout)
+		conn, err := c.dial(probeCtx, primary)
+		cancel()
+		if err != nil {
+			c.logger.Debug("primary CTI server side still unreachable", "address", primary, "error", err)
+			continue
+		}
+		conn.Close()
+
+		from, _ := c.sides.current()
+		c.logger.Info("primary CTI server side is back, failing back",
+			"from", sideName(from),
+			"to", sideName(0),
+			"address", primary)
+		c.sides.setActive(0)
+		c.disconnect()
+		return
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/config"
+	"ctiservice/internal/protocol"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// SessionEventHandler is called when an event message is received on one of
+// the sessions run by a Manager.
+type SessionEventHandler func(session string, msg protocol.Message)
+
+// ManagedSession is one named CTI session run by a Manager.
+type ManagedSession struct {
+	Name   string
+	Client *Client
+}
+
+// Manager runs several independently configured CTI sessions in one process.
+// Each session has its own Client, and so its own connection, heartbeat,
+// reconnect loop and state; events from all of them go to a shared handler
+// along with the session name.
+type Manager struct {
+	logger   *slog.Logger
+	sessions []ManagedSession
+}
+
+// NewManager creates a Manager with one session per config. Sessions are
+// named by Config.SessionName, or "default" when it is empty. With more than
+// one session, each session's logs carry a session attribute.
+func NewManager(cfgs []*config.Config, logger *slog.Logger, handler SessionEventHandler) *Manager {
+	m := &Manager{logger: logger}
+
+	for _, cfg := range cfgs {
+		name := cfg.SessionName
+		if name == "" {
+			name = "default"
+		}
+
+		var eventHandler EventHandler
+		if handler != nil {
+			eventHandler = func(msg protocol.Message) {
+				handler(name, msg)
+			}
+		}
+
+		sessionLogger := logger
+		if len(cfgs) > 1 {
+			sessionLogger = logger.With("session", name)
+		}
+
+		m.sessions = append(m.sessions, ManagedSession{
+			Name:   name,
+			Client: New(cfg, sessionLogger, eventHandler),
+		})
+	}
+
+	return m
+}
+
+// Run runs every session until the context is canceled. A session whose Run
+// fails, e.g. after exhausting its reconnect attempts, stops on its own while
+// the others keep running. Run returns once all sessions have stopped, with
+// ctx.Err() if the context was canceled and otherwise the session errors.
+func (m *Manager) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(m.sessions))
+
+	for i, s := range m.sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.Client.Run(ctx)
+			if err != nil && ctx.Err() == nil {
+				m.logger.Error("session stopped", "session", s.Name, "error", err)
+				errs[i] = fmt.Errorf("session %s: %w", s.Name, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return errors.Join(errs...)
+}
+
+// Sessions returns the managed sessions in configuration order.
+func (m *Manager) Sessions() []ManagedSession {
+	return m.sessions
+}
+
+// Session returns the client of the named session, or nil if there is none.
+func (m *Manager) Session(name string) *Client {
+	for _, s := range m.sessions {
+		if s.Name == name {
+			return s.Client
+		}
+	}
+	return nil
+}
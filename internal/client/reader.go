@@ -25,36 +25,45 @@ func NewReader(conn net.Conn) *Reader {
 
 // ReadMessage reads and parses a complete CTI message.
 func (r *Reader) ReadMessage() (protocol.Message, error) {
+	msg, _, err := r.ReadFrame()
+	return msg, err
+}
+
+// ReadFrame reads and parses a complete CTI message like ReadMessage, and
+// also returns the frame it was read from, header and body, as received.
+func (r *Reader) ReadFrame() (protocol.Message, []byte, error) {
 	// Read the 8-byte header
 	header, err := protocol.ReadHeader(r.conn)
 	if err != nil {
 		if err == io.EOF {
-			return nil, fmt.Errorf("connection closed: %w", err)
+			return nil, nil, fmt.Errorf("connection closed: %w", err)
 		}
-		return nil, fmt.Errorf("failed to read message header: %w", err)
+		return nil, nil, fmt.Errorf("failed to read message header: %w", err)
 	}
 
 	// Validate message length
 	if header.MessageLength > protocol.MaxMessageSize {
-		return nil, fmt.Errorf("message length %d exceeds maximum %d",
+		return nil, nil, fmt.Errorf("message length %d exceeds maximum %d",
 			header.MessageLength, protocol.MaxMessageSize)
 	}
 
-	// Read the message body
-	body := make([]byte, header.MessageLength)
+	// Read the message body after the header in one frame buffer
+	frame := make([]byte, protocol.HeaderSize+int(header.MessageLength))
+	copy(frame, header.Bytes())
+	body := frame[protocol.HeaderSize:]
 	if header.MessageLength > 0 {
 		if _, err := io.ReadFull(r.conn, body); err != nil {
-			return nil, fmt.Errorf("failed to read message body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read message body: %w", err)
 		}
 	}
 
 	// Parse the message
 	msg, err := r.registry.Parse(header.MessageType, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse message: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse message: %w", err)
 	}
 
-	return msg, nil
+	return msg, frame, nil
 }
 
 // RawMessage contains the header and unparsed body of a message.
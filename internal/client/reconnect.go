@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrReconnectAttemptsExhausted is returned by Run when the server stayed
+// unreachable for ReconnectMaxAttempts consecutive attempts.
+var ErrReconnectAttemptsExhausted = errors.New("reconnect attempts exhausted")
+
+// backoff computes reconnect delays using exponential backoff with full
+// jitter: the n-th consecutive retry waits a random duration in
+// [0, min(max, base*2^(n-1))], so clients that lost the server together do
+// not reconnect in lockstep.
+type backoff struct {
+	base        time.Duration
+	max         time.Duration
+	maxAttempts int // 0 = infinite
+	attempts    int // Consecutive retries since the last reset
+}
+
+// newBackoff creates a backoff from the base delay, ceiling and attempt budget.
+func newBackoff(base, max time.Duration, maxAttempts int) *backoff {
+	return &backoff{
+		base:        base,
+		max:         max,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// next returns the delay before the next retry, or false when the attempt
+// budget is exhausted.
+func (b *backoff) next() (time.Duration, bool) {
+	if b.maxAttempts > 0 && b.attempts >= b.maxAttempts {
+		return 0, false
+	}
+	b.attempts++
+
+	ceiling := b.base
+	for i := 1; i < b.attempts && ceiling < b.max; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, b.max)
+	if ceiling <= 0 {
+		return 0, true
+	}
+	return rand.N(ceiling + 1), true
+}
+
+// reset starts the backoff over after a healthy session.
+func (b *backoff) reset() {
+	b.attempts = 0
+}
+
+// waitForRetry waits out the next backoff delay before a reconnect attempt.
+// Returns ctx.Err() if the context ends first, or an error wrapping
+// ErrReconnectAttemptsExhausted once the attempt budget is spent.
+func (c *Client) waitForRetry(ctx context.Context) error {
+	delay, ok := c.backoff.next()
+	if !ok {
+		return fmt.Errorf("%w: gave up after %d attempts", ErrReconnectAttemptsExhausted, c.backoff.attempts)
+	}
+
+	c.logger.Info("waiting before retry",
+		"delay", delay,
+		"attempt", c.backoff.attempts,
+		"maxAttempts", c.backoff.maxAttempts)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffBounds(t *testing.T) {
+	const (
+		base = 100 * time.Millisecond
+		max  = time.Second
+	)
+	// Ceiling of each consecutive retry: base doubling up to max
+	ceilings := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+		time.Second,
+	}
+
+	for round := 0; round < 200; round++ {
+		b := newBackoff(base, max, 0)
+		for i, ceiling := range ceilings {
+			delay, ok := b.next()
+			if !ok {
+				t.Fatalf("retry %d: attempts exhausted without a limit", i+1)
+			}
+			if delay < 0 || delay > ceiling {
+				t.Fatalf("retry %d: delay %v outside [0, %v]", i+1, delay, ceiling)
+			}
+		}
+	}
+}
+
+// Full jitter must actually spread the delays over the range.
+func TestBackoffJitter(t *testing.T) {
+	b := newBackoff(time.Second, time.Second, 0)
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		delay, _ := b.next()
+		seen[delay] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("20 retries all waited %v", seen)
+	}
+}
+
+func TestBackoffMaxAttempts(t *testing.T) {
+	b := newBackoff(time.Millisecond, time.Millisecond, 3)
+	for i := 0; i < 3; i++ {
+		if _, ok := b.next(); !ok {
+			t.Fatalf("retry %d refused, want 3 allowed", i+1)
+		}
+	}
+	if _, ok := b.next(); ok {
+		t.Fatal("retry 4 allowed, want attempts exhausted after 3")
+	}
+
+	b.reset()
+	if _, ok := b.next(); !ok {
+		t.Fatal("retry refused after reset")
+	}
+}
+
+func TestBackoffReset(t *testing.T) {
+	b := newBackoff(100*time.Millisecond, time.Hour, 0)
+	for i := 0; i < 10; i++ {
+		b.next()
+	}
+	b.reset()
+
+	// After a reset the first retry is bounded by the base delay again
+	for i := 0; i < 100; i++ {
+		b.reset()
+		if delay, _ := b.next(); delay > 100*time.Millisecond {
+			t.Fatalf("first retry after reset waited %v, want at most the base delay", delay)
+		}
+	}
+}
+
+func TestBackoffZeroBase(t *testing.T) {
+	b := newBackoff(0, time.Second, 0)
+	for i := 0; i < 5; i++ {
+		if delay, ok := b.next(); !ok || delay != 0 {
+			t.Fatalf("retry %d = %v, %v; want 0, true", i+1, delay, ok)
+		}
+	}
+}
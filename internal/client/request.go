@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/protocol"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrConnectionLost is returned to requests still waiting for a
+	// confirmation when the connection to the CTI server drops.
+	ErrConnectionLost = errors.New("connection lost")
+
+	// ErrSessionNotOpen is returned, without sending anything, for requests
+	// made while no session is open.
+	ErrSessionNotOpen = errors.New("session not open")
+
+	// ErrNoInvokeID is returned by Do for a request whose InvokeID is 0.
+	ErrNoInvokeID = errors.New("request has no InvokeID")
+)
+
+// pendingResult is what a waiting request receives: its confirmation and the
+// frame it was read from, or the error that ended the wait.
+type pendingResult struct {
+	msg   protocol.Message
+	frame []byte
+	err   error
+}
+
+// pendingRequests correlates outstanding requests with their confirmations by InvokeID.
+type pendingRequests struct {
+	mu      sync.Mutex
+	waiters map[uint32]chan pendingResult
+}
+
+// newPendingRequests creates an empty pending request table.
+func newPendingRequests() *pendingRequests {
+	return &pendingRequests{
+		waiters: make(map[uint32]chan pendingResult),
+	}
+}
+
+// add registers a waiter for the given InvokeID.
+func (p *pendingRequests) add(invokeID uint32) chan pendingResult {
+	ch := make(chan pendingResult, 1)
+	p.mu.Lock()
+	p.waiters[invokeID] = ch
+	p.mu.Unlock()
+	return ch
+}
+
+// remove unregisters the waiter for the given InvokeID.
+func (p *pendingRequests) remove(invokeID uint32) {
+	p.mu.Lock()
+	delete(p.waiters, invokeID)
+	p.mu.Unlock()
+}
+
+// deliver hands a confirmation to the waiter registered for its InvokeID.
+// Returns false if no request is waiting for it.
+func (p *pendingRequests) deliver(invokeID uint32, msg protocol.Message, frame []byte) bool {
+	p.mu.Lock()
+	ch, ok := p.waiters[invokeID]
+	if ok {
+		delete(p.waiters, invokeID)
+	}
+	p.mu.Unlock()
+
+	if ok {
+		ch <- pendingResult{msg: msg, frame: frame}
+	}
+	return ok
+}
+
+// failAll ends the wait of every outstanding request with err.
+func (p *pendingRequests) failAll(err error) {
+	p.mu.Lock()
+	waiters := p.waiters
+	p.waiters = make(map[uint32]chan pendingResult)
+	p.mu.Unlock()
+
+	for _, ch := range waiters {
+		ch <- pendingResult{err: err}
+	}
+}
+
+// NextInvokeID returns a new InvokeID for a request passed to Do.
+func (c *Client) NextInvokeID() uint32 {
+	return c.session.NextInvokeID()
+}
+
+// Do sends req and blocks until the confirmation carrying its InvokeID
+// arrives, the server rejects it, the connection drops or ctx is done.
+// The InvokeID is read from req itself, which like every GED-188 request
+// carries it as its first fixed field; it must be assigned with NextInvokeID,
+// and a request with InvokeID 0 is rejected with ErrNoInvokeID.
+// A rejection is returned as a *CTIError, a dropped connection as
+// ErrConnectionLost, a request made with no open session as
+// ErrSessionNotOpen and one that cannot be answered because the dispatch
+// queue is full under the block policy as ErrDispatchBlocked.
+func (c *Client) Do(ctx context.Context, req protocol.Message) (protocol.Message, error) {
+	data, err := req.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", protocol.MessageTypeName(req.Type()), err)
+	}
+
+	r := protocol.NewFixedFieldReader(data)
+	invokeID := r.ReadUint32()
+	if err := r.Error(); err != nil {
+		return nil, fmt.Errorf("%s has no InvokeID: %w", protocol.MessageTypeName(req.Type()), err)
+	}
+	if invokeID == 0 {
+		return nil, fmt.Errorf("cannot send %s: %w", protocol.MessageTypeName(req.Type()), ErrNoInvokeID)
+	}
+
+	return c.request(ctx, invokeID, req)
+}
+
+// request sends a request and blocks until the confirmation carrying the same
+// InvokeID arrives, the server rejects it, the connection drops or the context is done.
+func (c *Client) request(ctx context.Context, invokeID uint32, req protocol.Message) (protocol.Message, error) {
+	msg, _, err := c.requestFrame(ctx, invokeID, req)
+	return msg, err
+}
+
+// requestFrame is request, also returning the frame the confirmation was read
+// from so that it can be dispatched like an event.
+func (c *Client) requestFrame(ctx context.Context, invokeID uint32, req protocol.Message) (protocol.Message, []byte, error) {
+	if !c.session.IsOpen() {
+		return nil, nil, fmt.Errorf("cannot send %s: %w",
+			protocol.MessageTypeName(req.Type()), ErrSessionNotOpen)
+	}
+
+	ch := c.pending.add(invokeID)
+	defer c.pending.remove(invokeID)
+
+	// The confirmation could not be read before the handler catches up, so
+	// fail now rather than deadlock a handler that made this request
+	if c.dispatcher.isBlocked() {
+		return nil, nil, fmt.Errorf("cannot send %s: %w",
+			protocol.MessageTypeName(req.Type()), ErrDispatchBlocked)
+	}
+
+	if err := c.sendMessage(req); err != nil {
+		return nil, nil, fmt.Errorf("failed to send %s: %w", protocol.MessageTypeName(req.Type()), err)
+	}
+
+	c.logger.Debug("sent request",
+		"type", protocol.MessageTypeName(req.Type()),
+		"invokeID", invokeID)
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, fmt.Errorf("waiting for response to %s: %w",
+			protocol.MessageTypeName(req.Type()), ctx.Err())
+	case res := <-ch:
+		if res.err != nil {
+			return nil, nil, fmt.Errorf("waiting for response to %s: %w",
+				protocol.MessageTypeName(req.Type()), res.err)
+		}
+		if err := failureError(req.Type(), res.msg); err != nil {
+			return nil, nil, err
+		}
+		return res.msg, res.frame, nil
+	}
+}
+
+// peripheralID returns the peripheral to address requests to: the caller's
+// value if set, otherwise the one granted in OPEN_CONF, otherwise the configured one.
+func (c *Client) peripheralID(id uint32) uint32 {
+	if id != 0 {
+		return id
+	}
+	if id := c.session.PeripheralID(); id != 0 {
+		return id
+	}
+	return c.cfg.PeripheralID
+}
@@ -0,0 +1,277 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/config"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client with an open session on one end of a pipe
+// and the other end, which plays the CTI server. The client reads and
+// dispatches messages until the test ends.
+func newTestClient(t *testing.T, handler EventHandler) (*Client, net.Conn) {
+	t.Helper()
+
+	c := New(config.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)), handler)
+	clientConn, serverConn := net.Pipe()
+	c.conn = clientConn
+	c.reader = NewReader(clientConn)
+	c.session.SetState(StateOpen)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	c.dispatcher.start()
+	go func() {
+		defer close(done)
+		c.processMessages(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		serverConn.Close()
+		<-done
+		c.dispatcher.stop()
+		clientConn.Close()
+	})
+	return c, serverConn
+}
+
+// serverRead reads the next request the client sent.
+func serverRead(t *testing.T, conn net.Conn) protocol.Message {
+	t.Helper()
+	msg, err := NewReader(conn).ReadMessage()
+	if err != nil {
+		t.Errorf("server read: %v", err)
+		return nil
+	}
+	return msg
+}
+
+// serverWrite sends msg to the client.
+func serverWrite(t *testing.T, conn net.Conn, msg protocol.Message) {
+	t.Helper()
+	data, err := protocol.EncodeMessage(msg)
+	if err != nil {
+		t.Errorf("server encode: %v", err)
+		return
+	}
+	if _, err := conn.Write(data); err != nil {
+		t.Errorf("server write: %v", err)
+	}
+}
+
+func TestDoCorrelatesByInvokeID(t *testing.T) {
+	c, server := newTestClient(t, nil)
+
+	// Answer both requests in reverse order
+	go func() {
+		first, _ := serverRead(t, server).(*messages.QueryAgentStateReq)
+		second, _ := serverRead(t, server).(*messages.QueryAgentStateReq)
+		if first == nil || second == nil {
+			return
+		}
+		for _, req := range []*messages.QueryAgentStateReq{second, first} {
+			serverWrite(t, server, &messages.QueryAgentStateConf{InvokeID: req.InvokeID, AgentID: req.AgentID})
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type result struct {
+		agentID string
+		resp    protocol.Message
+		err     error
+	}
+	results := make(chan result, 2)
+	for _, agentID := range []string{"1001", "1002"} {
+		req := &messages.QueryAgentStateReq{InvokeID: c.NextInvokeID(), AgentID: agentID}
+		go func() {
+			resp, err := c.Do(ctx, req)
+			results <- result{agentID, resp, err}
+		}()
+		// Let the first request reach the server before the second
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for range 2 {
+		r := <-results
+		if r.err != nil {
+			t.Fatalf("Do for agent %s: %v", r.agentID, r.err)
+		}
+		conf, ok := r.resp.(*messages.QueryAgentStateConf)
+		if !ok {
+			t.Fatalf("Do for agent %s returned %T", r.agentID, r.resp)
+		}
+		if conf.AgentID != r.agentID {
+			t.Errorf("request for agent %s got the confirmation for agent %s", r.agentID, conf.AgentID)
+		}
+	}
+}
+
+// The call control requests that predate Do are only reachable through it.
+func TestDoCallControl(t *testing.T) {
+	tests := []struct {
+		req   func(invokeID uint32) protocol.Message
+		reply func(invokeID uint32) protocol.Message
+	}{
+		{
+			req:   func(id uint32) protocol.Message { return &messages.HoldCallReq{InvokeID: id, ConnectionCallID: 100} },
+			reply: func(id uint32) protocol.Message { return &messages.HoldCallConf{InvokeID: id} },
+		},
+		{
+			req: func(id uint32) protocol.Message {
+				return &messages.RetrieveCallReq{InvokeID: id, ConnectionCallID: 100}
+			},
+			reply: func(id uint32) protocol.Message { return &messages.RetrieveCallConf{InvokeID: id} },
+		},
+		{
+			req: func(id uint32) protocol.Message {
+				return &messages.ConsultCallReq{InvokeID: id, ActiveConnectionCallID: 100}
+			},
+			reply: func(id uint32) protocol.Message { return &messages.ConsultCallConf{InvokeID: id} },
+		},
+		{
+			req: func(id uint32) protocol.Message {
+				return &messages.ConferenceCallReq{InvokeID: id, ActiveConnectionCallID: 100}
+			},
+			reply: func(id uint32) protocol.Message { return &messages.ConferenceCallConf{InvokeID: id} },
+		},
+		{
+			req: func(id uint32) protocol.Message {
+				return &messages.TransferCallReq{InvokeID: id, ActiveConnectionCallID: 100}
+			},
+			reply: func(id uint32) protocol.Message { return &messages.TransferCallConf{InvokeID: id} },
+		},
+	}
+
+	for _, tt := range tests {
+		c, server := newTestClient(t, nil)
+		invokeID := c.NextInvokeID()
+		req, conf := tt.req(invokeID), tt.reply(invokeID)
+		go func() {
+			if serverRead(t, server) != nil {
+				serverWrite(t, server, conf)
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := c.Do(ctx, req)
+		cancel()
+		if err != nil {
+			t.Errorf("Do(%T): %v", req, err)
+			continue
+		}
+		if reflect.TypeOf(resp) != reflect.TypeOf(conf) {
+			t.Errorf("Do(%T) returned %T, want %T", req, resp, conf)
+		}
+	}
+}
+
+func TestUnmatchedConfirmationIsDispatched(t *testing.T) {
+	events := make(chan protocol.Message, 1)
+	_, server := newTestClient(t, func(msg protocol.Message) {
+		events <- msg
+	})
+
+	serverWrite(t, server, &messages.AnswerCallConf{InvokeID: 999})
+
+	select {
+	case msg := <-events:
+		if conf, ok := msg.(*messages.AnswerCallConf); !ok || conf.InvokeID != 999 {
+			t.Errorf("handler got %#v, want the AnswerCallConf for InvokeID 999", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("confirmation without a waiting request was not dispatched")
+	}
+}
+
+func TestDoRejection(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply func(invokeID uint32) protocol.Message
+		want  error
+	}{
+		{
+			name: "FAILURE_CONF",
+			reply: func(invokeID uint32) protocol.Message {
+				return &messages.FailureConf{InvokeID: invokeID, Status: protocol.StatusInvalidState}
+			},
+			want: ErrInvalidState,
+		},
+		{
+			name: "CONTROL_FAILURE_CONF",
+			reply: func(invokeID uint32) protocol.Message {
+				return &messages.ControlFailureConf{InvokeID: invokeID, FailureCode: 1, PeripheralErrorCode: 77}
+			},
+			want: ErrControlFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, server := newTestClient(t, nil)
+			go func() {
+				if req, ok := serverRead(t, server).(*messages.AnswerCallReq); ok {
+					serverWrite(t, server, tt.reply(req.InvokeID))
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			req := &messages.AnswerCallReq{InvokeID: c.NextInvokeID()}
+			_, err := c.Do(ctx, req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Do error = %v, want %v", err, tt.want)
+			}
+			var ctiErr *CTIError
+			if !errors.As(err, &ctiErr) || ctiErr.InvokeID != req.InvokeID {
+				t.Errorf("Do error = %#v, want a *CTIError for InvokeID %d", err, req.InvokeID)
+			}
+		})
+	}
+}
+
+func TestDoConnectionLost(t *testing.T) {
+	c, server := newTestClient(t, nil)
+	go func() {
+		if serverRead(t, server) != nil {
+			c.disconnect()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := c.Do(ctx, &messages.AnswerCallReq{InvokeID: c.NextInvokeID()})
+	if !errors.Is(err, ErrConnectionLost) {
+		t.Fatalf("Do error = %v, want ErrConnectionLost", err)
+	}
+}
+
+func TestDoWithoutInvokeID(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+
+	_, err := c.Do(context.Background(), &messages.AnswerCallReq{})
+	if !errors.Is(err, ErrNoInvokeID) {
+		t.Fatalf("Do error = %v, want ErrNoInvokeID", err)
+	}
+}
+
+func TestDoSessionNotOpen(t *testing.T) {
+	c := New(config.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	_, err := c.Do(context.Background(), &messages.AnswerCallReq{InvokeID: c.NextInvokeID()})
+	if !errors.Is(err, ErrSessionNotOpen) {
+		t.Fatalf("Do error = %v, want ErrSessionNotOpen", err)
+	}
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"ctiservice/internal/messages"
+	"slices"
 	"sync"
 )
 
@@ -47,6 +49,9 @@ type Session struct {
 	serviceGranted uint32
 	peripheralID   uint32
 	agentState     uint16
+
+	// Device capabilities from QUERY_DEVICE_INFO_CONF, keyed by instrument
+	deviceInfo map[string]*messages.QueryDeviceInfoConf
 }
 
 // NewSession creates a new session tracker.
@@ -129,6 +134,36 @@ func (s *Session) AgentState() uint16 {
 	return s.agentState
 }
 
+// DeviceInfo returns a copy of the cached device info for an instrument, if
+// any.
+func (s *Session) DeviceInfo(instrument string) (*messages.QueryDeviceInfoConf, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	info, ok := s.deviceInfo[instrument]
+	if !ok {
+		return nil, false
+	}
+	return copyDeviceInfo(info), true
+}
+
+// SetDeviceInfo caches a copy of the device info for an instrument until the
+// session is reset.
+func (s *Session) SetDeviceInfo(instrument string, info *messages.QueryDeviceInfoConf) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deviceInfo == nil {
+		s.deviceInfo = make(map[string]*messages.QueryDeviceInfoConf)
+	}
+	s.deviceInfo[instrument] = copyDeviceInfo(info)
+}
+
+// copyDeviceInfo copies info so callers cannot change the cached entry.
+func copyDeviceInfo(info *messages.QueryDeviceInfoConf) *messages.QueryDeviceInfoConf {
+	c := *info
+	c.Lines = slices.Clone(info.Lines)
+	return &c
+}
+
 // Reset resets the session to disconnected state.
 func (s *Session) Reset() {
 	s.mu.Lock()
@@ -138,5 +173,6 @@ func (s *Session) Reset() {
 	s.serviceGranted = 0
 	s.peripheralID = 0
 	s.agentState = 0
+	s.deviceInfo = nil
 	// Don't reset invokeID - keep incrementing
 }
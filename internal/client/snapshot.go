@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"fmt"
+)
+
+// SnapshotCall returns the current state of the call at the given connection,
+// including its call data and every device on the call. It is used to rebuild
+// call state after a reconnect.
+func (c *Client) SnapshotCall(ctx context.Context, conn protocol.ConnectionID) (*messages.SnapshotCallConf, error) {
+	req := &messages.SnapshotCallReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.SnapshotCallConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to SNAPSHOT_CALL_REQ: %T", resp)
+	}
+	return conf, nil
+}
+
+// SnapshotDevice returns the calls currently present at the given instrument
+// and the state of each.
+func (c *Client) SnapshotDevice(ctx context.Context, instrument string) (*messages.SnapshotDeviceConf, error) {
+	req := &messages.SnapshotDeviceReq{
+		InvokeID:        c.session.NextInvokeID(),
+		PeripheralID:    c.peripheralID(0),
+		AgentInstrument: instrument,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.SnapshotDeviceConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to SNAPSHOT_DEVICE_REQ: %T", resp)
+	}
+	return conf, nil
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"fmt"
+	"time"
+)
+
+// QueryQueueStatistics returns real-time statistics for the given CSQs, or
+// for every CSQ when none are given.
+func (c *Client) QueryQueueStatistics(ctx context.Context, csqIDs ...uint32) (*messages.QueryQueueStatisticsConf, error) {
+	req := c.queueStatisticsReq(csqIDs)
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.QueryQueueStatisticsConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to QUERY_QUEUE_STATISTICS_REQ: %T", resp)
+	}
+	return conf, nil
+}
+
+// QuerySummaryStatistics returns contact center totals and per skill group statistics.
+func (c *Client) QuerySummaryStatistics(ctx context.Context) (*messages.QuerySummaryStatisticsConf, error) {
+	req := c.summaryStatisticsReq()
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.QuerySummaryStatisticsConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to QUERY_SUMMARY_STATISTICS_REQ: %T", resp)
+	}
+	return conf, nil
+}
+
+// QueryAgentQueueStatistics returns statistics for each CSQ the agent serves.
+func (c *Client) QueryAgentQueueStatistics(ctx context.Context, agentID string) (*messages.QueryAgentQueueStatisticsConf, error) {
+	req := c.agentQueueStatisticsReq(agentID)
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, ok := resp.(*messages.QueryAgentQueueStatisticsConf)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response to QUERY_AGENT_QUEUE_STATISTICS_REQ: %T", resp)
+	}
+	return conf, nil
+}
+
+// queueStatisticsReq builds a QUERY_QUEUE_STATISTICS_REQ for the given CSQs.
+func (c *Client) queueStatisticsReq(csqIDs []uint32) *messages.QueryQueueStatisticsReq {
+	return &messages.QueryQueueStatisticsReq{
+		InvokeID:     c.session.NextInvokeID(),
+		PeripheralID: c.peripheralID(0),
+		CSQIDs:       csqIDs,
+	}
+}
+
+// summaryStatisticsReq builds a QUERY_SUMMARY_STATISTICS_REQ.
+func (c *Client) summaryStatisticsReq() *messages.QuerySummaryStatisticsReq {
+	return &messages.QuerySummaryStatisticsReq{
+		InvokeID:     c.session.NextInvokeID(),
+		PeripheralID: c.peripheralID(0),
+	}
+}
+
+// agentQueueStatisticsReq builds a QUERY_AGENT_QUEUE_STATISTICS_REQ for an agent.
+func (c *Client) agentQueueStatisticsReq(agentID string) *messages.QueryAgentQueueStatisticsReq {
+	return &messages.QueryAgentQueueStatisticsReq{
+		InvokeID:     c.session.NextInvokeID(),
+		PeripheralID: c.peripheralID(0),
+		AgentID:      agentID,
+	}
+}
+
+// StatisticsPoll selects the statistics PollStatistics requests on each tick.
+type StatisticsPoll struct {
+	Interval time.Duration // Time between polls
+	Queues   bool          // Poll QUERY_QUEUE_STATISTICS
+	CSQIDs   []uint32      // CSQs to poll when Queues is set; empty polls all CSQs
+	Summary  bool          // Poll QUERY_SUMMARY_STATISTICS
+	AgentIDs []string      // Agents to poll QUERY_AGENT_QUEUE_STATISTICS for
+}
+
+// PollStatistics queries the selected statistics every poll.Interval until ctx
+// is done, delivering each confirmation to the EventHandler like any other
+// message. Ticks while the session is not open are skipped and failed queries
+// are logged, so polling survives reconnects. Always returns ctx.Err().
+func (c *Client) PollStatistics(ctx context.Context, poll StatisticsPoll) error {
+	if poll.Interval <= 0 {
+		return fmt.Errorf("invalid statistics poll interval: %v", poll.Interval)
+	}
+
+	ticker := time.NewTicker(poll.Interval)
+	defer ticker.Stop()
+
+	c.logger.Info("statistics polling started", "interval", poll.Interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("statistics polling stopped")
+			return ctx.Err()
+
+		case <-ticker.C:
+			if !c.session.IsOpen() {
+				continue
+			}
+			c.pollStatistics(ctx, poll)
+		}
+	}
+}
+
+// pollStatistics runs one round of the selected statistics queries.
+// Each round is bounded by the poll interval so a lost confirmation cannot
+// stall polling.
+func (c *Client) pollStatistics(ctx context.Context, poll StatisticsPoll) {
+	ctx, cancel := context.WithTimeout(ctx, poll.Interval)
+	defer cancel()
+
+	// Confirmations are dispatched with the frame they were read from, so
+	// they can be spilled like any other event
+	query := func(invokeID uint32, req protocol.Message, name string) {
+		msg, frame, err := c.requestFrame(ctx, invokeID, req)
+		if err != nil {
+			c.logger.Warn("statistics query failed", "query", name, "error", err)
+			return
+		}
+		c.dispatcher.dispatch(msg, frame)
+	}
+
+	if poll.Queues {
+		req := c.queueStatisticsReq(poll.CSQIDs)
+		query(req.InvokeID, req, "queue")
+	}
+	if poll.Summary {
+		req := c.summaryStatisticsReq()
+		query(req.InvokeID, req, "summary")
+	}
+	for _, agentID := range poll.AgentIDs {
+		req := c.agentQueueStatisticsReq(agentID)
+		query(req.InvokeID, req, "agent queue")
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"ctiservice/internal/protocol"
+	"fmt"
+)
+
+// SupervisorAssist requests assistance from the agent's supervisor for the
+// call at the given connection. Returns the new connection to the supervisor.
+func (c *Client) SupervisorAssist(ctx context.Context, conn protocol.ConnectionID, instrument string) (protocol.ConnectionID, error) {
+	req := &messages.SupervisorAssistReq{
+		InvokeID:               c.session.NextInvokeID(),
+		PeripheralID:           c.peripheralID(0),
+		ConnectionCallID:       conn.CallID,
+		ConnectionDeviceIDType: conn.DeviceIDType,
+		ConnectionDeviceID:     conn.DeviceID,
+		AgentInstrument:        instrument,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return protocol.ConnectionID{}, err
+	}
+
+	conf, ok := resp.(*messages.SupervisorAssistConf)
+	if !ok {
+		return protocol.ConnectionID{}, fmt.Errorf("unexpected response to SUPERVISOR_ASSIST_REQ: %T", resp)
+	}
+	return protocol.ConnectionID{
+		CallID:       conf.ConnectionCallID,
+		DeviceIDType: conf.ConnectionDeviceIDType,
+		DeviceID:     conf.ConnectionDeviceID,
+	}, nil
+}
+
+// SilentMonitor starts silently monitoring the agent's call from the
+// supervisor's instrument. Returns the supervisor's connection to the call,
+// which is needed to later barge in or intercept.
+func (c *Client) SilentMonitor(ctx context.Context, agent protocol.ConnectionID, supervisorInstrument string) (protocol.ConnectionID, error) {
+	return c.superviseCall(ctx, messages.SupervisorActionMonitor, agent, protocol.ConnectionID{}, supervisorInstrument)
+}
+
+// Barge joins the supervisor into the agent's call as a conference party.
+// supervisor is the connection returned by SilentMonitor.
+func (c *Client) Barge(ctx context.Context, agent, supervisor protocol.ConnectionID) error {
+	_, err := c.superviseCall(ctx, messages.SupervisorActionBarge, agent, supervisor, "")
+	return err
+}
+
+// Intercept takes over the agent's call, dropping the agent from it.
+// supervisor is the connection returned by SilentMonitor.
+func (c *Client) Intercept(ctx context.Context, agent, supervisor protocol.ConnectionID) error {
+	_, err := c.superviseCall(ctx, messages.SupervisorActionIntercept, agent, supervisor, "")
+	return err
+}
+
+// superviseCall sends SUPERVISE_CALL_REQ with the given action. It fails with
+// ErrServiceNotGranted unless the session was granted ServiceSupervisor.
+func (c *Client) superviseCall(ctx context.Context, action messages.SupervisorAction, agent, supervisor protocol.ConnectionID, instrument string) (protocol.ConnectionID, error) {
+	if c.session.ServiceGranted()&protocol.ServiceSupervisor == 0 {
+		return protocol.ConnectionID{}, fmt.Errorf("%s (%s): %w: supervisor",
+			protocol.MessageTypeName(protocol.MsgTypeSuperviseCallReq),
+			messages.SupervisorActionName(action), ErrServiceNotGranted)
+	}
+
+	req := &messages.SuperviseCallReq{
+		InvokeID:                         c.session.NextInvokeID(),
+		PeripheralID:                     c.peripheralID(0),
+		AgentConnectionCallID:            agent.CallID,
+		AgentConnectionDeviceIDType:      agent.DeviceIDType,
+		AgentConnectionDeviceID:          agent.DeviceID,
+		SupervisorConnectionCallID:       supervisor.CallID,
+		SupervisorConnectionDeviceIDType: supervisor.DeviceIDType,
+		SupervisorConnectionDeviceID:     supervisor.DeviceID,
+		SupervisoryAction:                uint16(action),
+		SupervisorInstrument:             instrument,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return protocol.ConnectionID{}, err
+	}
+
+	conf, ok := resp.(*messages.SuperviseCallConf)
+	if !ok {
+		return protocol.ConnectionID{}, fmt.Errorf("unexpected response to SUPERVISE_CALL_REQ: %T", resp)
+	}
+	return protocol.ConnectionID{
+		CallID:       conf.ConnectionCallID,
+		DeviceIDType: conf.ConnectionDeviceIDType,
+		DeviceID:     conf.ConnectionDeviceID,
+	}, nil
+}
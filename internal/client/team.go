@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"ctiservice/internal/messages"
+	"fmt"
+)
+
+// RequestTeamConfig asks the server for the configuration of the given team,
+// or of every team visible to the session when teamID is 0, and returns the
+// number of teams reported. Each team arrives as a TeamConfigEvent through the
+// EventHandler; the events are read before the confirmation but dispatched
+// asynchronously, so the handler may not have seen them all when this returns.
+func (c *Client) RequestTeamConfig(ctx context.Context, teamID uint32) (int, error) {
+	req := &messages.TeamConfigReq{
+		InvokeID:     c.session.NextInvokeID(),
+		PeripheralID: c.peripheralID(0),
+		TeamID:       teamID,
+	}
+
+	resp, err := c.request(ctx, req.InvokeID, req)
+	if err != nil {
+		return 0, err
+	}
+
+	conf, ok := resp.(*messages.TeamConfigConf)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response to TEAM_CONFIG_REQ: %T", resp)
+	}
+	return int(conf.NumTeams), nil
+}
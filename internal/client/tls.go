@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// dialTimeout bounds establishing the TCP connection and the TLS handshake.
+const dialTimeout = 30 * time.Second
+
+// dial opens the connection to the CTI server at addr, over TLS when enabled.
+// The returned connection is used the same way in both cases.
+func (c *Client) dial(ctx context.Context, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+	}
+
+	if !c.cfg.TLSEnabled {
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	tlsCfg, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config:    tlsCfg,
+	}
+	return tlsDialer.DialContext(ctx, "tcp", addr)
+}
+
+// tlsConfig builds the TLS client configuration from the TLS settings.
+// Certificates are read on every call, so rotated files are picked up on
+// the next reconnect.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName: c.cfg.TLSServerName,
+		MinVersion: c.cfg.TLSMinVersion,
+	}
+
+	if c.cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(c.cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file %s", c.cfg.TLSCAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if c.cfg.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.cfg.TLSCertFile, c.cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
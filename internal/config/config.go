@@ -2,25 +2,70 @@
 package config
 
 import (
+	"crypto/tls"
 	"ctiservice/internal/protocol"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Dispatch overflow policies, applied when a dispatch queue is full.
+const (
+	OverflowBlock      = "block"       // Stop reading until the handler catches up
+	OverflowDropOldest = "drop-oldest" // Discard the oldest queued event
+	OverflowSpill      = "spill"       // Queue further events on disk
+)
+
+// ServerEndpoint is the address of one side of a duplex CTI server pair.
+type ServerEndpoint struct {
+	Host string
+	Port int
+}
+
+// String returns the endpoint as host:port.
+func (e ServerEndpoint) String() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
 // Config holds the configuration for the CTI client.
 type Config struct {
+	// SessionName identifies the session when several run in one process;
+	// empty for a single session
+	SessionName string
+
 	// Server connection
 	ServerHost string
 	ServerPort int
 
+	// Duplex failover
+	Servers          []ServerEndpoint // Ordered server sides (A, B, ...); the first is the primary
+	FailbackInterval time.Duration    // How often to probe the primary while on another side, 0 = never
+
+	// TLS transport
+	TLSEnabled    bool
+	TLSCAFile     string // PEM CA bundle to verify the server; empty uses the system roots
+	TLSCertFile   string // PEM client certificate for mutual TLS
+	TLSKeyFile    string // PEM client private key for mutual TLS
+	TLSServerName string // Overrides the server name verified in the certificate
+	TLSMinVersion uint16 // tls.VersionTLS12 or tls.VersionTLS13
+
 	// Session settings
 	ClientID          string
 	PeripheralID      uint32
 	ServicesRequested uint32
 	IdleTimeout       time.Duration
 
+	// OPEN_REQ authentication and agent-mode fields
+	ClientPassword    string // Read from CTI_CLIENT_PASSWORD_FILE; never logged
+	ClientSignature   string
+	AgentExtension    string // Agent-mode sessions identify the agent by extension,
+	AgentID           string // ID or instrument
+	AgentInstrument   string
+	ApplicationPathID int32 // 0 = not sent
+
 	// Event subscription masks for OPEN_REQ
 	CallMsgMask       uint32 // Bitmask for call events to receive
 	AgentStateMask    uint32 // Bitmask for agent state events to receive
@@ -30,8 +75,16 @@ type Config struct {
 	HeartbeatInterval time.Duration
 
 	// Reconnection settings
-	ReconnectDelay       time.Duration
-	ReconnectMaxAttempts int // 0 = infinite
+	ReconnectDelay       time.Duration // Base delay, doubled after each failed attempt
+	ReconnectMaxDelay    time.Duration // Ceiling for the backoff delay
+	ReconnectResetAfter  time.Duration // Session uptime after which the backoff starts over
+	ReconnectMaxAttempts int           // 0 = infinite
+
+	// Event dispatch
+	DispatchQueueSize int    // Events queued per worker before the overflow policy applies
+	DispatchWorkers   int    // Handler goroutines; events of one call stay on one worker, and above 1 the handler runs concurrently
+	DispatchOverflow  string // OverflowBlock, OverflowDropOldest or OverflowSpill
+	DispatchSpillDir  string // Directory for spill files, empty = os.TempDir()
 
 	// Logging
 	LogLevel string
@@ -42,6 +95,8 @@ func DefaultConfig() *Config {
 	return &Config{
 		ServerHost:           "localhost",
 		ServerPort:           42027,
+		FailbackInterval:     time.Minute,
+		TLSMinVersion:        tls.VersionTLS12,
 		ClientID:             "CTIService",
 		PeripheralID:         0,
 		ServicesRequested:    protocol.ServiceAllEvents | protocol.ServiceClientEvents,
@@ -51,20 +106,71 @@ func DefaultConfig() *Config {
 		ConfigMsgMask:        protocol.ConfigMaskAll,     // Subscribe to all config events
 		HeartbeatInterval:    30 * time.Second,
 		ReconnectDelay:       10 * time.Second,
+		ReconnectMaxDelay:    5 * time.Minute,
+		ReconnectResetAfter:  time.Minute,
 		ReconnectMaxAttempts: 0,
+		DispatchQueueSize:    1024,
+		DispatchWorkers:      1,
+		DispatchOverflow:     OverflowBlock,
 		LogLevel:             "info",
 	}
 }
 
 // LoadFromEnv loads configuration from environment variables.
 func LoadFromEnv() (*Config, error) {
+	return load(os.Getenv)
+}
+
+// LoadSessionsFromEnv loads one configuration per CTI session. CTI_SESSIONS
+// names the sessions (e.g. "siteA,siteB"); each session reads
+// CTI_SESSION_<NAME>_<VAR> before falling back to the shared CTI_<VAR>, so
+// CTI_SESSION_SITEA_PERIPHERAL_ID overrides CTI_PERIPHERAL_ID for siteA only.
+// The CTI_SESSION_ prefix keeps overrides apart from the shared variables,
+// and names are letters and digits only so that the name ends at the next
+// underscore. Without CTI_SESSIONS a single unnamed session is loaded as by
+// LoadFromEnv.
+func LoadSessionsFromEnv() ([]*Config, error) {
+	v := os.Getenv("CTI_SESSIONS")
+	if v == "" {
+		cfg, err := LoadFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return []*Config{cfg}, nil
+	}
+
+	names, err := parseSessionNames(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CTI_SESSIONS: %w", err)
+	}
+
+	cfgs := make([]*Config, 0, len(names))
+	for _, name := range names {
+		prefix := "CTI_SESSION_" + strings.ToUpper(name) + "_"
+		cfg, err := load(func(key string) string {
+			if v := os.Getenv(prefix + strings.TrimPrefix(key, "CTI_")); v != "" {
+				return v
+			}
+			return os.Getenv(key)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("session %s: %w", name, err)
+		}
+		cfg.SessionName = name
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, nil
+}
+
+// load builds a configuration from the variables returned by getenv.
+func load(getenv func(string) string) (*Config, error) {
 	cfg := DefaultConfig()
 
-	if v := os.Getenv("CTI_SERVER_HOST"); v != "" {
+	if v := getenv("CTI_SERVER_HOST"); v != "" {
 		cfg.ServerHost = v
 	}
 
-	if v := os.Getenv("CTI_SERVER_PORT"); v != "" {
+	if v := getenv("CTI_SERVER_PORT"); v != "" {
 		port, err := strconv.Atoi(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_SERVER_PORT: %w", err)
@@ -72,11 +178,91 @@ func LoadFromEnv() (*Config, error) {
 		cfg.ServerPort = port
 	}
 
-	if v := os.Getenv("CTI_CLIENT_ID"); v != "" {
+	if v := getenv("CTI_SERVERS"); v != "" {
+		servers, err := parseServers(v, cfg.ServerPort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_SERVERS: %w", err)
+		}
+		cfg.Servers = servers
+	}
+
+	if v := getenv("CTI_FAILBACK_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_FAILBACK_INTERVAL: %w", err)
+		}
+		cfg.FailbackInterval = d
+	}
+
+	if v := getenv("CTI_TLS_ENABLED"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_TLS_ENABLED: %w", err)
+		}
+		cfg.TLSEnabled = enabled
+	}
+
+	if v := getenv("CTI_TLS_CA_FILE"); v != "" {
+		cfg.TLSCAFile = v
+	}
+
+	if v := getenv("CTI_TLS_CERT_FILE"); v != "" {
+		cfg.TLSCertFile = v
+	}
+
+	if v := getenv("CTI_TLS_KEY_FILE"); v != "" {
+		cfg.TLSKeyFile = v
+	}
+
+	if v := getenv("CTI_TLS_SERVER_NAME"); v != "" {
+		cfg.TLSServerName = v
+	}
+
+	if v := getenv("CTI_TLS_MIN_VERSION"); v != "" {
+		version, err := parseTLSVersion(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_TLS_MIN_VERSION: %w", err)
+		}
+		cfg.TLSMinVersion = version
+	}
+
+	if v := getenv("CTI_CLIENT_ID"); v != "" {
 		cfg.ClientID = v
 	}
 
-	if v := os.Getenv("CTI_PERIPHERAL_ID"); v != "" {
+	if v := getenv("CTI_CLIENT_PASSWORD_FILE"); v != "" {
+		password, err := readSecretFile(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_CLIENT_PASSWORD_FILE: %w", err)
+		}
+		cfg.ClientPassword = password
+	}
+
+	if v := getenv("CTI_CLIENT_SIGNATURE"); v != "" {
+		cfg.ClientSignature = v
+	}
+
+	if v := getenv("CTI_AGENT_EXTENSION"); v != "" {
+		cfg.AgentExtension = v
+	}
+
+	if v := getenv("CTI_AGENT_ID"); v != "" {
+		cfg.AgentID = v
+	}
+
+	if v := getenv("CTI_AGENT_INSTRUMENT"); v != "" {
+		cfg.AgentInstrument = v
+	}
+
+	if v := getenv("CTI_APPLICATION_PATH_ID"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_APPLICATION_PATH_ID: %w", err)
+		}
+		cfg.ApplicationPathID = int32(id)
+	}
+
+	if v := getenv("CTI_PERIPHERAL_ID"); v != "" {
 		id, err := strconv.ParseUint(v, 10, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_PERIPHERAL_ID: %w", err)
@@ -84,7 +270,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.PeripheralID = uint32(id)
 	}
 
-	if v := os.Getenv("CTI_SERVICES_REQUESTED"); v != "" {
+	if v := getenv("CTI_SERVICES_REQUESTED"); v != "" {
 		services, err := strconv.ParseUint(v, 0, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_SERVICES_REQUESTED: %w", err)
@@ -92,7 +278,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.ServicesRequested = uint32(services)
 	}
 
-	if v := os.Getenv("CTI_CALL_MSG_MASK"); v != "" {
+	if v := getenv("CTI_CALL_MSG_MASK"); v != "" {
 		mask, err := strconv.ParseUint(v, 0, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_CALL_MSG_MASK: %w", err)
@@ -100,7 +286,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.CallMsgMask = uint32(mask)
 	}
 
-	if v := os.Getenv("CTI_AGENT_STATE_MASK"); v != "" {
+	if v := getenv("CTI_AGENT_STATE_MASK"); v != "" {
 		mask, err := strconv.ParseUint(v, 0, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_AGENT_STATE_MASK: %w", err)
@@ -108,7 +294,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.AgentStateMask = uint32(mask)
 	}
 
-	if v := os.Getenv("CTI_CONFIG_MSG_MASK"); v != "" {
+	if v := getenv("CTI_CONFIG_MSG_MASK"); v != "" {
 		mask, err := strconv.ParseUint(v, 0, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_CONFIG_MSG_MASK: %w", err)
@@ -116,7 +302,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.ConfigMsgMask = uint32(mask)
 	}
 
-	if v := os.Getenv("CTI_IDLE_TIMEOUT"); v != "" {
+	if v := getenv("CTI_IDLE_TIMEOUT"); v != "" {
 		d, err := time.ParseDuration(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_IDLE_TIMEOUT: %w", err)
@@ -124,7 +310,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.IdleTimeout = d
 	}
 
-	if v := os.Getenv("CTI_HEARTBEAT_INTERVAL"); v != "" {
+	if v := getenv("CTI_HEARTBEAT_INTERVAL"); v != "" {
 		d, err := time.ParseDuration(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_HEARTBEAT_INTERVAL: %w", err)
@@ -132,7 +318,7 @@ func LoadFromEnv() (*Config, error) {
 		cfg.HeartbeatInterval = d
 	}
 
-	if v := os.Getenv("CTI_RECONNECT_DELAY"); v != "" {
+	if v := getenv("CTI_RECONNECT_DELAY"); v != "" {
 		d, err := time.ParseDuration(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_RECONNECT_DELAY: %w", err)
@@ -140,7 +326,23 @@ func LoadFromEnv() (*Config, error) {
 		cfg.ReconnectDelay = d
 	}
 
-	if v := os.Getenv("CTI_RECONNECT_MAX_ATTEMPTS"); v != "" {
+	if v := getenv("CTI_RECONNECT_MAX_DELAY"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_RECONNECT_MAX_DELAY: %w", err)
+		}
+		cfg.ReconnectMaxDelay = d
+	}
+
+	if v := getenv("CTI_RECONNECT_RESET_AFTER"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_RECONNECT_RESET_AFTER: %w", err)
+		}
+		cfg.ReconnectResetAfter = d
+	}
+
+	if v := getenv("CTI_RECONNECT_MAX_ATTEMPTS"); v != "" {
 		attempts, err := strconv.Atoi(v)
 		if err != nil {
 			return nil, fmt.Errorf("invalid CTI_RECONNECT_MAX_ATTEMPTS: %w", err)
@@ -148,20 +350,151 @@ func LoadFromEnv() (*Config, error) {
 		cfg.ReconnectMaxAttempts = attempts
 	}
 
-	if v := os.Getenv("CTI_LOG_LEVEL"); v != "" {
+	if v := getenv("CTI_DISPATCH_QUEUE_SIZE"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_DISPATCH_QUEUE_SIZE: %w", err)
+		}
+		cfg.DispatchQueueSize = size
+	}
+
+	if v := getenv("CTI_DISPATCH_WORKERS"); v != "" {
+		workers, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CTI_DISPATCH_WORKERS: %w", err)
+		}
+		cfg.DispatchWorkers = workers
+	}
+
+	if v := getenv("CTI_DISPATCH_OVERFLOW"); v != "" {
+		cfg.DispatchOverflow = v
+	}
+
+	if v := getenv("CTI_DISPATCH_SPILL_DIR"); v != "" {
+		cfg.DispatchSpillDir = v
+	}
+
+	if v := getenv("CTI_LOG_LEVEL"); v != "" {
 		cfg.LogLevel = v
 	}
 
 	return cfg, nil
 }
 
+// parseSessionNames parses a comma-separated list of session names. Names are
+// letters and digits only, unique regardless of case since they are
+// upper-cased into variable names.
+func parseSessionNames(v string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty session name")
+		}
+		for _, r := range name {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+				return nil, fmt.Errorf("invalid session name %q", name)
+			}
+		}
+		key := strings.ToUpper(name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate session name %q", name)
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// parseServers parses a comma-separated list of host:port endpoints.
+// Entries without a port use defaultPort.
+func parseServers(v string, defaultPort int) ([]ServerEndpoint, error) {
+	var servers []ServerEndpoint
+	for _, entry := range strings.Split(v, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		host, portStr, err := net.SplitHostPort(entry)
+		if err != nil {
+			servers = append(servers, ServerEndpoint{Host: entry, Port: defaultPort})
+			continue
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in %q: %w", entry, err)
+		}
+		servers = append(servers, ServerEndpoint{Host: host, Port: port})
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no servers listed")
+	}
+	return servers, nil
+}
+
+// readSecretFile returns the contents of a secret file without the trailing
+// newline most editors add. The error does not include the contents.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// parseTLSVersion parses a TLS version such as "1.2" or "1.3".
+func parseTLSVersion(v string) (uint16, error) {
+	switch v {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q (want 1.2 or 1.3)", v)
+	}
+}
+
+// Endpoints returns the CTI server sides in order of preference: Servers if
+// set, otherwise the single ServerHost/ServerPort.
+func (c *Config) Endpoints() []ServerEndpoint {
+	if len(c.Servers) > 0 {
+		return c.Servers
+	}
+	return []ServerEndpoint{{Host: c.ServerHost, Port: c.ServerPort}}
+}
+
 // Validate checks if the configuration is valid.
 func (c *Config) Validate() error {
-	if c.ServerHost == "" {
-		return fmt.Errorf("server host is required")
+	for _, e := range c.Endpoints() {
+		if e.Host == "" {
+			return fmt.Errorf("server host is required")
+		}
+		if e.Port <= 0 || e.Port > 65535 {
+			return fmt.Errorf("invalid server port: %d", e.Port)
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS client certificate and key must be set together")
+	}
+	for _, f := range []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"client ID", c.ClientID, 64},
+		{"client password", c.ClientPassword, 64},
+		{"client signature", c.ClientSignature, 64},
+		{"agent extension", c.AgentExtension, 16},
+		{"agent ID", c.AgentID, 12},
+		{"agent instrument", c.AgentInstrument, 64},
+	} {
+		if len(f.value) > f.max {
+			return fmt.Errorf("%s longer than %d bytes", f.name, f.max)
+		}
 	}
-	if c.ServerPort <= 0 || c.ServerPort > 65535 {
-		return fmt.Errorf("invalid server port: %d", c.ServerPort)
+	if c.FailbackInterval < 0 {
+		return fmt.Errorf("invalid failback interval: %v", c.FailbackInterval)
 	}
 	if c.HeartbeatInterval < time.Second {
 		return fmt.Errorf("heartbeat interval too short: %v", c.HeartbeatInterval)
@@ -169,13 +502,38 @@ func (c *Config) Validate() error {
 	if c.IdleTimeout < c.HeartbeatInterval*4 {
 		return fmt.Errorf("idle timeout should be at least 4x heartbeat interval")
 	}
+	if c.ReconnectDelay <= 0 {
+		return fmt.Errorf("invalid reconnect delay: %v", c.ReconnectDelay)
+	}
+	if c.ReconnectMaxDelay < c.ReconnectDelay {
+		return fmt.Errorf("reconnect max delay %v is shorter than reconnect delay %v", c.ReconnectMaxDelay, c.ReconnectDelay)
+	}
+	if c.ReconnectMaxAttempts < 0 {
+		return fmt.Errorf("invalid reconnect max attempts: %d", c.ReconnectMaxAttempts)
+	}
+	if c.ConfigMsgMask != protocol.ConfigMaskAll && c.ConfigMsgMask > 0xFFFF {
+		return fmt.Errorf("config message mask 0x%08X does not fit the 16-bit CONFIG_REQUEST_EVENT type", c.ConfigMsgMask)
+	}
+	if c.DispatchQueueSize < 1 {
+		return fmt.Errorf("invalid dispatch queue size: %d", c.DispatchQueueSize)
+	}
+	if c.DispatchWorkers < 1 {
+		return fmt.Errorf("invalid dispatch workers: %d", c.DispatchWorkers)
+	}
+	switch c.DispatchOverflow {
+	case OverflowBlock, OverflowDropOldest, OverflowSpill:
+	default:
+		return fmt.Errorf("invalid dispatch overflow policy %q (want %s, %s or %s)",
+			c.DispatchOverflow, OverflowBlock, OverflowDropOldest, OverflowSpill)
+	}
 	return nil
 }
 
 // String returns a string representation of the config (for logging).
+// ClientPassword is deliberately left out.
 func (c *Config) String() string {
 	return fmt.Sprintf(
-		"Config{ServerHost=%s, ServerPort=%d, ClientID=%s, HeartbeatInterval=%v, IdleTimeout=%v}",
-		c.ServerHost, c.ServerPort, c.ClientID, c.HeartbeatInterval, c.IdleTimeout,
+		"Config{Servers=%v, PeripheralID=%d, TLS=%t, ClientID=%s, AgentID=%s, HeartbeatInterval=%v, IdleTimeout=%v}",
+		c.Endpoints(), c.PeripheralID, c.TLSEnabled, c.ClientID, c.AgentID, c.HeartbeatInterval, c.IdleTimeout,
 	)
 }
@@ -19,6 +19,32 @@ func (f EventHandlerFunc) Handle(msg protocol.Message) {
 	f(msg)
 }
 
+// SessionHandler is implemented by handlers that receive events from several
+// named CTI sessions.
+type SessionHandler interface {
+	// HandleSession processes a CTI message received on the named session.
+	HandleSession(session string, msg protocol.Message)
+}
+
+// SessionHandlerFunc is a function type that implements SessionHandler.
+type SessionHandlerFunc func(session string, msg protocol.Message)
+
+// HandleSession implements SessionHandler.
+func (f SessionHandlerFunc) HandleSession(session string, msg protocol.Message) {
+	f(session, msg)
+}
+
+// ForSession returns a SessionHandler that passes the session name to h if it
+// implements SessionHandler, and otherwise calls h.Handle without it.
+func ForSession(h EventHandler) SessionHandler {
+	if sh, ok := h.(SessionHandler); ok {
+		return sh
+	}
+	return SessionHandlerFunc(func(session string, msg protocol.Message) {
+		h.Handle(msg)
+	})
+}
+
 // MultiHandler dispatches events to multiple handlers.
 type MultiHandler struct {
 	handlers []EventHandler
@@ -36,6 +62,14 @@ func (m *MultiHandler) Handle(msg protocol.Message) {
 	}
 }
 
+// HandleSession dispatches the message and its session name to all registered
+// handlers.
+func (m *MultiHandler) HandleSession(session string, msg protocol.Message) {
+	for _, h := range m.handlers {
+		ForSession(h).HandleSession(session, msg)
+	}
+}
+
 // Add adds a handler to the multi-handler.
 func (m *MultiHandler) Add(h EventHandler) {
 	m.handlers = append(m.handlers, h)
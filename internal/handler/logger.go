@@ -3,6 +3,7 @@ package handler
 import (
 	"ctiservice/internal/messages"
 	"ctiservice/internal/protocol"
+	"fmt"
 	"log/slog"
 )
 
@@ -18,14 +19,24 @@ func NewLogHandler(logger *slog.Logger) *LogHandler {
 
 // Handle logs the received message with appropriate details.
 func (h *LogHandler) Handle(msg protocol.Message) {
+	h.log(msg)
+}
+
+// HandleSession logs the message like Handle, tagged with the session name.
+func (h *LogHandler) HandleSession(session string, msg protocol.Message) {
+	h.log(msg, "session", session)
+}
+
+// log logs msg with the given leading attributes.
+func (h *LogHandler) log(msg protocol.Message, attrs ...any) {
 	msgType := msg.Type()
 	msgName := protocol.MessageTypeName(msgType)
 
 	// Create base attributes
-	attrs := []any{
+	attrs = append(attrs,
 		"messageType", msgName,
 		"messageTypeID", msgType,
-	}
+	)
 
 	switch m := msg.(type) {
 	case *messages.BeginCallEvent:
@@ -170,6 +181,18 @@ func (h *LogHandler) Handle(msg protocol.Message) {
 				"transferredDeviceID", m.TransferredDeviceID,
 			)...)
 
+	case *messages.CallDivertedEvent:
+		h.logger.Info("call diverted",
+			append(attrs,
+				"callID", m.ConnectionCallID,
+				"monitorID", m.MonitorID,
+				"peripheralID", m.PeripheralID,
+				"eventCause", m.EventCause,
+				"connectionDeviceID", m.ConnectionDeviceID,
+				"divertingDeviceID", m.DivertingDeviceID,
+				"calledDeviceID", m.CalledDeviceID,
+			)...)
+
 	case *messages.CallQueuedEvent:
 		h.logger.Info("call queued",
 			append(attrs,
@@ -239,6 +262,15 @@ func (h *LogHandler) Handle(msg protocol.Message) {
 				"status", m.Status,
 			)...)
 
+	case *messages.ControlFailureConf:
+		h.logger.Error("control failure confirmation",
+			append(attrs,
+				"invokeID", m.InvokeID,
+				"failure", m.FailureName(),
+				"failureCode", m.FailureCode,
+				"peripheralErrorCode", m.PeripheralErrorCode,
+			)...)
+
 	case *messages.FailureEvent:
 		h.logger.Error("failure event",
 			append(attrs,
@@ -298,39 +330,119 @@ func (h *LogHandler) Handle(msg protocol.Message) {
 				"connectionDeviceID", m.ConnectionDeviceID,
 			)...)
 
+	case *messages.QueryQueueStatisticsConf:
+		h.logger.Info("queue statistics",
+			append(attrs,
+				"invokeID", m.InvokeID,
+				"numQueues", len(m.Queues),
+				"queues", m.Queues,
+			)...)
+
+	case *messages.QuerySummaryStatisticsConf:
+		h.logger.Info("summary statistics",
+			append(attrs,
+				"invokeID", m.InvokeID,
+				"callsInQueue", m.TotalCallsInQueue,
+				"longestWaitTime", m.LongestWaitTime,
+				"agentsLoggedIn", m.TotalAgentsLoggedIn,
+				"agentsReady", m.TotalAgentsReady,
+				"agentsNotReady", m.TotalAgentsNotReady,
+				"agentsTalking", m.TotalAgentsTalking,
+				"agentsWork", m.TotalAgentsWork,
+				"skillGroups", m.SkillGroups,
+			)...)
+
+	case *messages.QueryAgentQueueStatisticsConf:
+		h.logger.Info("agent queue statistics",
+			append(attrs,
+				"invokeID", m.InvokeID,
+				"agentID", m.AgentID,
+				"numQueues", len(m.Queues),
+				"queues", m.Queues,
+			)...)
+
+	case *messages.TeamConfigEvent:
+		supervisors := make([]string, 0)
+		for _, member := range m.Members {
+			if member.IsSupervisor() {
+				supervisors = append(supervisors, member.AgentID)
+			}
+		}
+		h.logger.Info("team config event",
+			append(attrs,
+				"peripheralID", m.PeripheralID,
+				"teamID", m.TeamID,
+				"teamName", m.TeamName,
+				"operation", m.OperationName(),
+				"numMembers", len(m.Members),
+				"supervisors", supervisors,
+			)...)
+
+	case *messages.TeamConfigConf:
+		h.logger.Info("team config complete",
+			append(attrs,
+				"invokeID", m.InvokeID,
+				"numTeams", m.NumTeams,
+			)...)
+
 	case *messages.ConfigAgentEvent:
+		agentIDs := make([]string, 0, len(m.Records))
+		for _, rec := range m.Records {
+			agentIDs = append(agentIDs, rec.AgentID)
+		}
 		h.logger.Info("config agent event",
 			append(attrs,
 				"peripheralID", m.PeripheralID,
 				"operation", m.OperationName(),
-				"numRecords", m.NumRecords,
-				"agentID", m.AgentID,
-				"agentExtension", m.AgentExtension,
-				"loginID", m.LoginID,
-				"firstName", m.FirstName,
-				"lastName", m.LastName,
+				"numRecords", len(m.Records),
+				"agentIDs", agentIDs,
 			)...)
 
 	case *messages.ConfigDeviceEvent:
+		deviceIDs := make([]string, 0, len(m.Records))
+		for _, rec := range m.Records {
+			deviceIDs = append(deviceIDs, rec.DeviceID)
+		}
 		h.logger.Info("config device event",
 			append(attrs,
 				"peripheralID", m.PeripheralID,
 				"operation", m.OperationName(),
-				"numRecords", m.NumRecords,
-				"extension", m.Extension,
-				"skillGroupID", m.SkillGroupID,
-				"serviceID", m.ServiceID,
+				"numRecords", len(m.Records),
+				"deviceIDs", deviceIDs,
 			)...)
 
 	case *messages.ConfigCSQEvent:
+		csqIDs := make([]uint32, 0, len(m.Records))
+		for _, rec := range m.Records {
+			csqIDs = append(csqIDs, rec.CSQID)
+		}
 		h.logger.Info("config CSQ event",
 			append(attrs,
 				"peripheralID", m.PeripheralID,
 				"operation", m.OperationName(),
-				"numRecords", m.NumRecords,
-				"csqID", m.CSQID,
-				"skillGroupID", m.SkillGroupID,
-				"serviceID", m.ServiceID,
+				"numRecords", len(m.Records),
+				"csqIDs", csqIDs,
+			)...)
+
+	case *messages.ConfigApplicationEvent:
+		applications := make([]string, 0, len(m.Records))
+		for _, rec := range m.Records {
+			applications = append(applications, rec.ApplicationName)
+		}
+		h.logger.Info("config application event",
+			append(attrs,
+				"peripheralID", m.PeripheralID,
+				"operation", m.OperationName(),
+				"numRecords", len(m.Records),
+				"applications", applications,
+			)...)
+
+	case *messages.ConfigKeyEvent:
+		h.logger.Info("config key event",
+			append(attrs,
+				"invokeID", m.InvokeID,
+				"peripheralID", m.PeripheralID,
+				"configKey", fmt.Sprintf("%x", m.ConfigKey),
 			)...)
 
 	case *messages.ConfigBeginEvent:
@@ -348,6 +460,40 @@ func (h *LogHandler) Handle(msg protocol.Message) {
 				"numRecords", m.NumRecords,
 			)...)
 
+	case *messages.RTPStartedEvent:
+		h.logger.Info("rtp started",
+			append(attrs,
+				"callID", m.ConnectionCallID,
+				"monitorID", m.MonitorID,
+				"peripheralID", m.PeripheralID,
+				"connectionDeviceID", m.ConnectionDeviceID,
+				"direction", m.DirectionName(),
+				"rtpType", m.RTPTypeName(),
+				"codec", m.CodecName(),
+				"packetSize", m.PacketSize,
+				"bitRate", m.BitRate,
+				"sendingAddress", m.SendingAddress,
+				"sendingPort", m.SendingPort,
+				"receivingAddress", m.ReceivingAddress,
+				"receivingPort", m.ReceivingPort,
+				"agentID", m.AgentID,
+			)...)
+
+	case *messages.RTPStoppedEvent:
+		h.logger.Info("rtp stopped",
+			append(attrs,
+				"callID", m.ConnectionCallID,
+				"monitorID", m.MonitorID,
+				"peripheralID", m.PeripheralID,
+				"connectionDeviceID", m.ConnectionDeviceID,
+				"direction", m.DirectionName(),
+				"sendingAddress", m.SendingAddress,
+				"sendingPort", m.SendingPort,
+				"receivingAddress", m.ReceivingAddress,
+				"receivingPort", m.ReceivingPort,
+				"agentID", m.AgentID,
+			)...)
+
 	case *messages.GenericMessage:
 		h.logger.Debug("unknown message received",
 			append(attrs,
@@ -0,0 +1,372 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// SkillGroup represents one skill group entry in agent state messages.
+type SkillGroup struct {
+	SkillGroupNumber   uint32 // Tag 9
+	SkillGroupID       uint32 // Tag 10
+	SkillGroupPriority uint16 // Tag 11
+	SkillGroupState    uint16 // Tag 115 (QUERY_AGENT_STATE_CONF only)
+}
+
+// decodeSkillGroups reads the repeated skill group fields of an agent state
+// message. Fields are walked in order since optional fields may be absent
+// from some groups; each Skill Group Number starts a new group.
+func decodeSkillGroups(data []byte) ([]SkillGroup, error) {
+	var groups []SkillGroup
+	p := protocol.NewFloatingFieldParser(data)
+	for p.HasMore() {
+		tag, data, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		if tag == protocol.TagSkillGroupNumber {
+			groups = append(groups, SkillGroup{SkillGroupNumber: protocol.FieldUint32(data)})
+			continue
+		}
+		if len(groups) == 0 {
+			continue
+		}
+
+		sg := &groups[len(groups)-1]
+		switch tag {
+		case protocol.TagSkillGroupID:
+			sg.SkillGroupID = protocol.FieldUint32(data)
+		case protocol.TagSkillGroupPriority:
+			sg.SkillGroupPriority = protocol.FieldUint16(data)
+		case protocol.TagSkillGroupState:
+			sg.SkillGroupState = protocol.FieldUint16(data)
+		}
+	}
+	return groups, nil
+}
+
+// SetAgentStateReq is sent to log an agent in or out or change its state.
+// Protocol Version 24 - SET_AGENT_STATE_REQ (MessageType = 38)
+type SetAgentStateReq struct {
+	// Fixed Part
+	InvokeID        uint32 // Client-assigned request ID (UINT)
+	PeripheralID    uint32 // Peripheral ID (UINT)
+	AgentState      uint16 // Requested agent state (USHORT)
+	AgentWorkMode   uint16 // Agent work mode (USHORT)
+	NumSkillGroups  uint16 // Number of skill groups (USHORT)
+	EventReasonCode uint16 // Reason code for NotReady/LoggedOut (USHORT)
+	ForcedFlag      bool   // Force the state change (BOOL)
+	AgentServiceReq uint32 // Agent services requested (UINT)
+
+	// Floating fields
+	AgentInstrument string // Tag 5 (max 64 bytes)
+	AgentID         string // Tag 4 (max 12 bytes)
+	AgentPassword   string // Tag 99 (max 64 bytes)
+	PositionID      string // Tag 3 - agent extension (max 16 bytes)
+
+	// SkillGroups contains repeating skill group info (up to NumSkillGroups).
+	// Only SkillGroupNumber and SkillGroupPriority are sent.
+	SkillGroups []SkillGroup
+}
+
+func (m *SetAgentStateReq) Type() uint32 {
+	return protocol.MsgTypeSetAgentStateReq
+}
+
+func (m *SetAgentStateReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(m.AgentState)
+	w.WriteUint16(m.AgentWorkMode)
+	w.WriteUint16(uint16(len(m.SkillGroups)))
+	w.WriteUint16(m.EventReasonCode)
+	w.WriteBool(m.ForcedFlag)
+	w.WriteUint32(m.AgentServiceReq)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+	if m.AgentPassword != "" {
+		fw.WriteString(protocol.TagAgentPassword, m.AgentPassword)
+	}
+	if m.PositionID != "" {
+		fw.WriteString(protocol.TagAgentExtension, m.PositionID)
+	}
+
+	// Write SkillGroup repeated fields
+	for _, sg := range m.SkillGroups {
+		fw.WriteUint32(protocol.TagSkillGroupNumber, sg.SkillGroupNumber)
+		fw.WriteUint16(protocol.TagSkillGroupPriority, sg.SkillGroupPriority)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SetAgentStateReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.AgentState = r.ReadUint16()
+	m.AgentWorkMode = r.ReadUint16()
+	m.NumSkillGroups = r.ReadUint16()
+	m.EventReasonCode = r.ReadUint16()
+	m.ForcedFlag = r.ReadBool()
+	m.AgentServiceReq = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+		m.AgentPassword = ff.GetString(protocol.TagAgentPassword)
+		m.PositionID = ff.GetString(protocol.TagAgentExtension)
+
+		m.SkillGroups, err = decodeSkillGroups(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetAgentStateConf confirms a SET_AGENT_STATE_REQ.
+// Protocol Version 24 - SET_AGENT_STATE_CONF (MessageType = 39)
+type SetAgentStateConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches SetAgentStateReq InvokeID (UINT)
+}
+
+func (m *SetAgentStateConf) Type() uint32 {
+	return protocol.MsgTypeSetAgentStateConf
+}
+
+func (m *SetAgentStateConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *SetAgentStateConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SetAgentStateConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// QueryAgentStateReq is sent to retrieve an agent's current state.
+// Protocol Version 24 - QUERY_AGENT_STATE_REQ (MessageType = 36)
+type QueryAgentStateReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+	MRDID        int32  // Media routing domain ID (INT)
+	ICMAgentID   int32  // ICM agent ID (INT)
+
+	// Floating fields
+	AgentInstrument string // Tag 5 (max 64 bytes)
+	AgentID         string // Tag 4 (max 12 bytes)
+}
+
+func (m *QueryAgentStateReq) Type() uint32 {
+	return protocol.MsgTypeQueryAgentStateReq
+}
+
+func (m *QueryAgentStateReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteInt32(m.MRDID)
+	w.WriteInt32(m.ICMAgentID)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryAgentStateReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.MRDID = r.ReadInt32()
+	m.ICMAgentID = r.ReadInt32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+	}
+
+	return nil
+}
+
+// QueryAgentStateConf returns an agent's current state.
+// Protocol Version 24 - QUERY_AGENT_STATE_CONF (MessageType = 37)
+type QueryAgentStateConf struct {
+	// Fixed Part
+	InvokeID                uint32 // Matches QueryAgentStateReq InvokeID (UINT)
+	AgentState              uint16 // Current agent state (USHORT)
+	NumSkillGroups          uint16 // Number of skill groups (USHORT)
+	MRDID                   int32  // Media routing domain ID (INT)
+	NumTasks                uint32 // Number of active tasks (UINT)
+	AgentMode               uint16 // Agent mode (USHORT)
+	MaxTaskLimit            uint32 // Maximum task limit (UINT)
+	ICMAgentID              int32  // ICM agent ID (INT)
+	AgentAvailabilityStatus uint32 // Availability status (UINT)
+	DepartmentID            int32  // Department ID (INT)
+
+	// Floating fields
+	AgentID         string // Tag 4 (max 12 bytes)
+	AgentExtension  string // Tag 3 (max 16 bytes)
+	AgentInstrument string // Tag 5 (max 64 bytes)
+
+	// SkillGroups contains repeating skill group info (up to NumSkillGroups)
+	SkillGroups []SkillGroup
+}
+
+func (m *QueryAgentStateConf) Type() uint32 {
+	return protocol.MsgTypeQueryAgentStateConf
+}
+
+func (m *QueryAgentStateConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(m.AgentState)
+	w.WriteUint16(uint16(len(m.SkillGroups)))
+	w.WriteInt32(m.MRDID)
+	w.WriteUint32(m.NumTasks)
+	w.WriteUint16(m.AgentMode)
+	w.WriteUint32(m.MaxTaskLimit)
+	w.WriteInt32(m.ICMAgentID)
+	w.WriteUint32(m.AgentAvailabilityStatus)
+	w.WriteInt32(m.DepartmentID)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+	if m.AgentExtension != "" {
+		fw.WriteString(protocol.TagAgentExtension, m.AgentExtension)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	// Write SkillGroup repeated fields
+	for _, sg := range m.SkillGroups {
+		fw.WriteUint32(protocol.TagSkillGroupNumber, sg.SkillGroupNumber)
+		fw.WriteUint32(protocol.TagSkillGroupID, sg.SkillGroupID)
+		fw.WriteUint16(protocol.TagSkillGroupPriority, sg.SkillGroupPriority)
+		fw.WriteUint16(protocol.TagSkillGroupState, sg.SkillGroupState)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryAgentStateConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.AgentState = r.ReadUint16()
+	m.NumSkillGroups = r.ReadUint16()
+	m.MRDID = r.ReadInt32()
+	m.NumTasks = r.ReadUint32()
+	m.AgentMode = r.ReadUint16()
+	m.MaxTaskLimit = r.ReadUint32()
+	m.ICMAgentID = r.ReadInt32()
+	m.AgentAvailabilityStatus = r.ReadUint32()
+	m.DepartmentID = r.ReadInt32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+		m.AgentExtension = ff.GetString(protocol.TagAgentExtension)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+
+		m.SkillGroups, err = decodeSkillGroups(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *QueryAgentStateConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// StateName returns the human-readable name for the agent state.
+func (m *QueryAgentStateConf) StateName() string {
+	return protocol.AgentStateName(m.AgentState)
+}
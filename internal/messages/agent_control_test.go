@@ -0,0 +1,113 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestAgentControlRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&SetAgentStateReq{
+			InvokeID:        3,
+			PeripheralID:    5000,
+			AgentState:      protocol.AgentStateReady,
+			AgentWorkMode:   1,
+			NumSkillGroups:  2,
+			EventReasonCode: 7,
+			ForcedFlag:      true,
+			AgentServiceReq: 1,
+			AgentInstrument: "4001",
+			AgentID:         "1001",
+			AgentPassword:   "pw",
+			PositionID:      "4001",
+			SkillGroups: []SkillGroup{
+				{SkillGroupNumber: 10, SkillGroupPriority: 1},
+				{SkillGroupNumber: 11, SkillGroupPriority: 2},
+			},
+		},
+		&SetAgentStateConf{InvokeID: 3},
+		&QueryAgentStateReq{
+			InvokeID:        4,
+			PeripheralID:    5000,
+			MRDID:           1,
+			ICMAgentID:      -1,
+			AgentInstrument: "4001",
+			AgentID:         "1001",
+		},
+		&QueryAgentStateConf{
+			InvokeID:                4,
+			AgentState:              protocol.AgentStateTalking,
+			NumSkillGroups:          1,
+			MRDID:                   1,
+			NumTasks:                2,
+			AgentMode:               1,
+			MaxTaskLimit:            3,
+			ICMAgentID:              5150,
+			AgentAvailabilityStatus: 1,
+			DepartmentID:            -1,
+			AgentID:                 "1001",
+			AgentExtension:          "4001",
+			AgentInstrument:         "4001",
+			SkillGroups: []SkillGroup{
+				{SkillGroupNumber: 10, SkillGroupID: 5010, SkillGroupPriority: 1, SkillGroupState: 3},
+			},
+		},
+	})
+}
+
+// Skill groups that leave out optional fields must not shift the following
+// groups' fields onto them.
+func TestQueryAgentStateConfDecodeSparseSkillGroups(t *testing.T) {
+	fixed, err := (&QueryAgentStateConf{InvokeID: 4}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteString(protocol.TagAgentID, "1001")
+	fw.WriteUint32(protocol.TagSkillGroupNumber, 10)
+	fw.WriteUint16(protocol.TagSkillGroupState, 3)
+	fw.WriteUint32(protocol.TagSkillGroupNumber, 11)
+	fw.WriteUint32(protocol.TagSkillGroupID, 5011)
+	fw.WriteUint16(protocol.TagSkillGroupPriority, 2)
+	fw.WriteUint32(protocol.TagSkillGroupNumber, 12)
+	fw.WriteUint16(protocol.TagSkillGroupState, 4)
+
+	var m QueryAgentStateConf
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SkillGroup{
+		{SkillGroupNumber: 10, SkillGroupState: 3},
+		{SkillGroupNumber: 11, SkillGroupID: 5011, SkillGroupPriority: 2},
+		{SkillGroupNumber: 12, SkillGroupState: 4},
+	}
+	if m.AgentID != "1001" || !reflect.DeepEqual(m.SkillGroups, want) {
+		t.Errorf("AgentID %q, SkillGroups =\n%+v\nwant 1001 and\n%+v", m.AgentID, m.SkillGroups, want)
+	}
+}
+
+func TestSetAgentStateReqDecodeSparseSkillGroups(t *testing.T) {
+	fixed, err := (&SetAgentStateReq{InvokeID: 3}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteUint32(protocol.TagSkillGroupNumber, 10)
+	fw.WriteUint32(protocol.TagSkillGroupNumber, 11)
+	fw.WriteUint16(protocol.TagSkillGroupPriority, 2)
+
+	var m SetAgentStateReq
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SkillGroup{
+		{SkillGroupNumber: 10},
+		{SkillGroupNumber: 11, SkillGroupPriority: 2},
+	}
+	if !reflect.DeepEqual(m.SkillGroups, want) {
+		t.Errorf("SkillGroups =\n%+v\nwant\n%+v", m.SkillGroups, want)
+	}
+}
@@ -213,6 +213,11 @@ func (m *ConsultCallConf) Decode(data []byte) error {
 	return nil
 }
 
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *ConsultCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
 // ConferenceCallReq is sent to create a conference call.
 // Protocol Version 24 - CONFERENCE_CALL_REQ (MessageType = 48)
 type ConferenceCallReq struct {
@@ -365,6 +370,11 @@ func (m *ConferenceCallConf) Decode(data []byte) error {
 	return nil
 }
 
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *ConferenceCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
 // TransferCallReq is sent to transfer a call.
 // Protocol Version 24 - TRANSFER_CALL_REQ (MessageType = 64)
 type TransferCallReq struct {
@@ -517,6 +527,11 @@ func (m *TransferCallConf) Decode(data []byte) error {
 	return nil
 }
 
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *TransferCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
 // HoldCallReq is sent to place a call on hold.
 // Protocol Version 24 - HOLD_CALL_REQ (MessageType = 54)
 type HoldCallReq struct {
@@ -609,6 +624,11 @@ func (m *HoldCallConf) Decode(data []byte) error {
 	return r.Error()
 }
 
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *HoldCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
 // RetrieveCallReq is sent to retrieve a held call.
 // Protocol Version 24 - RETRIEVE_CALL_REQ (MessageType = 62)
 type RetrieveCallReq struct {
@@ -700,3 +720,1172 @@ func (m *RetrieveCallConf) Decode(data []byte) error {
 	m.InvokeID = r.ReadUint32()
 	return r.Error()
 }
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *RetrieveCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// MakeCallReq is sent to place an outbound call from an agent's instrument.
+// Protocol Version 24 - MAKE_CALL_REQ (MessageType = 56)
+type MakeCallReq struct {
+	// Fixed Part
+	InvokeID          uint32 // Client-assigned request ID (UINT)
+	PeripheralID      uint32 // Peripheral ID (UINT)
+	CallPlacementType uint16 // Call placement type (USHORT)
+	CallMannerType    uint16 // Call manner type (USHORT)
+	AlertRings        uint16 // Rings before the call is considered unanswered (USHORT)
+	CallOption        uint16 // Peripheral-specific call option (USHORT)
+	FacilityType      uint16 // Facility type (USHORT)
+	AnsweringMachine  uint16 // Answering machine detection (USHORT)
+	Priority          bool   // Priority call (BOOL)
+	PostRoute         bool   // Post-route the call (BOOL)
+	// NumNamedVariables and NumNamedArrays (USHORT) are derived from
+	// NamedVariables and NamedArrays when encoding.
+
+	// Floating fields
+	AgentInstrument   string // Tag 5
+	DialedNumber      string // Tag 40
+	AuthorizationCode string // Tag 77
+	AccountCode       string // Tag 78
+	UserToUserInfo    string // Tag 17
+	CallVariable1     string // Tag 18
+	CallVariable2     string // Tag 19
+	CallVariable3     string // Tag 20
+	CallVariable4     string // Tag 21
+	CallVariable5     string // Tag 22
+	CallVariable6     string // Tag 23
+	CallVariable7     string // Tag 24
+	CallVariable8     string // Tag 25
+	CallVariable9     string // Tag 26
+	CallVariable10    string // Tag 27
+	CallWrapupData    string // Tag 30
+
+	// ECC data
+	NamedVariables map[string]string   // Tag 82 - keyed by variable name
+	NamedArrays    map[string][]string // Tag 83 - keyed by array name, indexed by element
+}
+
+func (m *MakeCallReq) Type() uint32 {
+	return protocol.MsgTypeMakeCallReq
+}
+
+func (m *MakeCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(m.CallPlacementType)
+	w.WriteUint16(m.CallMannerType)
+	w.WriteUint16(m.AlertRings)
+	w.WriteUint16(m.CallOption)
+	w.WriteUint16(m.FacilityType)
+	w.WriteUint16(m.AnsweringMachine)
+	w.WriteBool(m.Priority)
+	w.WriteBool(m.PostRoute)
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+	if m.DialedNumber != "" {
+		fw.WriteString(protocol.TagDialedNumber, m.DialedNumber)
+	}
+	if m.AuthorizationCode != "" {
+		fw.WriteString(protocol.TagAuthorizationCode, m.AuthorizationCode)
+	}
+	if m.AccountCode != "" {
+		fw.WriteString(protocol.TagAccountCode, m.AccountCode)
+	}
+	if m.UserToUserInfo != "" {
+		fw.WriteString(protocol.TagUserToUserInfo, m.UserToUserInfo)
+	}
+	if m.CallVariable1 != "" {
+		fw.WriteString(protocol.TagCallVariable1, m.CallVariable1)
+	}
+	if m.CallVariable2 != "" {
+		fw.WriteString(protocol.TagCallVariable2, m.CallVariable2)
+	}
+	if m.CallVariable3 != "" {
+		fw.WriteString(protocol.TagCallVariable3, m.CallVariable3)
+	}
+	if m.CallVariable4 != "" {
+		fw.WriteString(protocol.TagCallVariable4, m.CallVariable4)
+	}
+	if m.CallVariable5 != "" {
+		fw.WriteString(protocol.TagCallVariable5, m.CallVariable5)
+	}
+	if m.CallVariable6 != "" {
+		fw.WriteString(protocol.TagCallVariable6, m.CallVariable6)
+	}
+	if m.CallVariable7 != "" {
+		fw.WriteString(protocol.TagCallVariable7, m.CallVariable7)
+	}
+	if m.CallVariable8 != "" {
+		fw.WriteString(protocol.TagCallVariable8, m.CallVariable8)
+	}
+	if m.CallVariable9 != "" {
+		fw.WriteString(protocol.TagCallVariable9, m.CallVariable9)
+	}
+	if m.CallVariable10 != "" {
+		fw.WriteString(protocol.TagCallVariable10, m.CallVariable10)
+	}
+	if m.CallWrapupData != "" {
+		fw.WriteString(protocol.TagCallWrapupData, m.CallWrapupData)
+	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *MakeCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.CallPlacementType = r.ReadUint16()
+	m.CallMannerType = r.ReadUint16()
+	m.AlertRings = r.ReadUint16()
+	m.CallOption = r.ReadUint16()
+	m.FacilityType = r.ReadUint16()
+	m.AnsweringMachine = r.ReadUint16()
+	m.Priority = r.ReadBool()
+	m.PostRoute = r.ReadBool()
+	r.ReadUint16() // NumNamedVariables
+	r.ReadUint16() // NumNamedArrays
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+		m.DialedNumber = ff.GetString(protocol.TagDialedNumber)
+		m.AuthorizationCode = ff.GetString(protocol.TagAuthorizationCode)
+		m.AccountCode = ff.GetString(protocol.TagAccountCode)
+		m.UserToUserInfo = ff.GetString(protocol.TagUserToUserInfo)
+		m.CallVariable1 = ff.GetString(protocol.TagCallVariable1)
+		m.CallVariable2 = ff.GetString(protocol.TagCallVariable2)
+		m.CallVariable3 = ff.GetString(protocol.TagCallVariable3)
+		m.CallVariable4 = ff.GetString(protocol.TagCallVariable4)
+		m.CallVariable5 = ff.GetString(protocol.TagCallVariable5)
+		m.CallVariable6 = ff.GetString(protocol.TagCallVariable6)
+		m.CallVariable7 = ff.GetString(protocol.TagCallVariable7)
+		m.CallVariable8 = ff.GetString(protocol.TagCallVariable8)
+		m.CallVariable9 = ff.GetString(protocol.TagCallVariable9)
+		m.CallVariable10 = ff.GetString(protocol.TagCallVariable10)
+		m.CallWrapupData = ff.GetString(protocol.TagCallWrapupData)
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
+	}
+
+	return nil
+}
+
+// MakeCallConf is the server's response to MakeCallReq.
+// Protocol Version 24 - MAKE_CALL_CONF (MessageType = 57)
+type MakeCallConf struct {
+	// Fixed Part
+	InvokeID                uint32 // Matches MakeCallReq InvokeID (UINT)
+	NewConnectionCallID     uint32 // Call ID of the new call (UINT)
+	NewConnectionDeviceType uint16 // New connection device type (USHORT)
+	LineHandle              uint16 // Line handle (USHORT)
+	LineType                uint16 // Line type (USHORT)
+	Reserved                uint16 // Reserved (USHORT)
+
+	// Floating fields
+	NewConnectionDeviceID string // Tag 186
+}
+
+func (m *MakeCallConf) Type() uint32 {
+	return protocol.MsgTypeMakeCallConf
+}
+
+func (m *MakeCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.NewConnectionCallID)
+	w.WriteUint16(m.NewConnectionDeviceType)
+	w.WriteUint16(m.LineHandle)
+	w.WriteUint16(m.LineType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.NewConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagNewConnectionDeviceID, m.NewConnectionDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *MakeCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.NewConnectionCallID = r.ReadUint32()
+	m.NewConnectionDeviceType = r.ReadUint16()
+	m.LineHandle = r.ReadUint16()
+	m.LineType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.NewConnectionDeviceID = ff.GetString(protocol.TagNewConnectionDeviceID)
+	}
+
+	return nil
+}
+
+func (m *MakeCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// AnswerCallReq is sent to answer an alerting call.
+// Protocol Version 24 - ANSWER_CALL_REQ (MessageType = 42)
+type AnswerCallReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Connection device type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	AgentInstrument    string // Tag 5
+}
+
+func (m *AnswerCallReq) Type() uint32 {
+	return protocol.MsgTypeAnswerCallReq
+}
+
+func (m *AnswerCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *AnswerCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// AnswerCallConf is the server's response to AnswerCallReq.
+// Protocol Version 24 - ANSWER_CALL_CONF (MessageType = 43)
+type AnswerCallConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches AnswerCallReq InvokeID (UINT)
+}
+
+func (m *AnswerCallConf) Type() uint32 {
+	return protocol.MsgTypeAnswerCallConf
+}
+
+func (m *AnswerCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *AnswerCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+func (m *AnswerCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// ClearCallReq is sent to release all parties from a call.
+// Protocol Version 24 - CLEAR_CALL_REQ (MessageType = 44)
+type ClearCallReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Connection device type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	AgentInstrument    string // Tag 5
+}
+
+func (m *ClearCallReq) Type() uint32 {
+	return protocol.MsgTypeClearCallReq
+}
+
+func (m *ClearCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *ClearCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// ClearCallConf is the server's response to ClearCallReq.
+// Protocol Version 24 - CLEAR_CALL_CONF (MessageType = 45)
+type ClearCallConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches ClearCallReq InvokeID (UINT)
+}
+
+func (m *ClearCallConf) Type() uint32 {
+	return protocol.MsgTypeClearCallConf
+}
+
+func (m *ClearCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *ClearCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+func (m *ClearCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// ClearConnectionReq is sent to release a single party from a call.
+// Protocol Version 24 - CLEAR_CONNECTION_REQ (MessageType = 46)
+type ClearConnectionReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Connection device type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	AgentInstrument    string // Tag 5
+}
+
+func (m *ClearConnectionReq) Type() uint32 {
+	return protocol.MsgTypeClearConnectionReq
+}
+
+func (m *ClearConnectionReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *ClearConnectionReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// ClearConnectionConf is the server's response to ClearConnectionReq.
+// Protocol Version 24 - CLEAR_CONNECTION_CONF (MessageType = 47)
+type ClearConnectionConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches ClearConnectionReq InvokeID (UINT)
+}
+
+func (m *ClearConnectionConf) Type() uint32 {
+	return protocol.MsgTypeClearConnectionConf
+}
+
+func (m *ClearConnectionConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *ClearConnectionConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+func (m *ClearConnectionConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// AlternateCallReq is sent to place the active call on hold and retrieve the held call
+// in a single step.
+// Protocol Version 24 - ALTERNATE_CALL_REQ (MessageType = 40)
+type AlternateCallReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ActiveConnectionCallID uint32 // Active call ID (UINT)
+	ActiveConnectionType   uint16 // Active connection device type (USHORT)
+	HeldConnectionCallID   uint32 // Held call ID (UINT)
+	HeldConnectionType     uint16 // Held connection device type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ActiveConnectionDeviceID string // Tag 31
+	HeldConnectionDeviceID   string // Tag 34
+}
+
+func (m *AlternateCallReq) Type() uint32 {
+	return protocol.MsgTypeAlternateCallReq
+}
+
+func (m *AlternateCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ActiveConnectionCallID)
+	w.WriteUint16(m.ActiveConnectionType)
+	w.WriteUint32(m.HeldConnectionCallID)
+	w.WriteUint16(m.HeldConnectionType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ActiveConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ActiveConnectionDeviceID)
+	}
+	if m.HeldConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagHoldingDeviceID, m.HeldConnectionDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *AlternateCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ActiveConnectionCallID = r.ReadUint32()
+	m.ActiveConnectionType = r.ReadUint16()
+	m.HeldConnectionCallID = r.ReadUint32()
+	m.HeldConnectionType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ActiveConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.HeldConnectionDeviceID = ff.GetString(protocol.TagHoldingDeviceID)
+	}
+
+	return nil
+}
+
+// AlternateCallConf is the server's response to AlternateCallReq.
+// Protocol Version 24 - ALTERNATE_CALL_CONF (MessageType = 41)
+type AlternateCallConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches AlternateCallReq InvokeID (UINT)
+}
+
+func (m *AlternateCallConf) Type() uint32 {
+	return protocol.MsgTypeAlternateCallConf
+}
+
+func (m *AlternateCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *AlternateCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+func (m *AlternateCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// ReconnectCallReq is sent to clear the active (consult) call and retrieve the held call.
+// Protocol Version 24 - RECONNECT_CALL_REQ (MessageType = 60)
+type ReconnectCallReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ActiveConnectionCallID uint32 // Active call ID (UINT)
+	ActiveConnectionType   uint16 // Active connection device type (USHORT)
+	HeldConnectionCallID   uint32 // Held call ID (UINT)
+	HeldConnectionType     uint16 // Held connection device type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ActiveConnectionDeviceID string // Tag 31
+	HeldConnectionDeviceID   string // Tag 34
+}
+
+func (m *ReconnectCallReq) Type() uint32 {
+	return protocol.MsgTypeReconnectCallReq
+}
+
+func (m *ReconnectCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ActiveConnectionCallID)
+	w.WriteUint16(m.ActiveConnectionType)
+	w.WriteUint32(m.HeldConnectionCallID)
+	w.WriteUint16(m.HeldConnectionType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ActiveConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ActiveConnectionDeviceID)
+	}
+	if m.HeldConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagHoldingDeviceID, m.HeldConnectionDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *ReconnectCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ActiveConnectionCallID = r.ReadUint32()
+	m.ActiveConnectionType = r.ReadUint16()
+	m.HeldConnectionCallID = r.ReadUint32()
+	m.HeldConnectionType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ActiveConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.HeldConnectionDeviceID = ff.GetString(protocol.TagHoldingDeviceID)
+	}
+
+	return nil
+}
+
+// ReconnectCallConf is the server's response to ReconnectCallReq.
+// Protocol Version 24 - RECONNECT_CALL_CONF (MessageType = 61)
+type ReconnectCallConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches ReconnectCallReq InvokeID (UINT)
+}
+
+func (m *ReconnectCallConf) Type() uint32 {
+	return protocol.MsgTypeReconnectCallConf
+}
+
+func (m *ReconnectCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *ReconnectCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+func (m *ReconnectCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// CallDataUpdate holds the call data written by SET_CALL_DATA_REQ.
+// Only non-nil fields are sent, so unset values are left unchanged on the
+// call; a pointer to an empty string clears the value. Named variables and
+// arrays are sent when non-empty.
+type CallDataUpdate struct {
+	ANI                 *string // Tag 15
+	UserToUserInfo      *string // Tag 17
+	DNIS                *string // Tag 16
+	DialedNumber        *string // Tag 40
+	CallerEnteredDigits *string // Tag 41
+	CallVariable1       *string // Tag 18
+	CallVariable2       *string // Tag 19
+	CallVariable3       *string // Tag 20
+	CallVariable4       *string // Tag 21
+	CallVariable5       *string // Tag 22
+	CallVariable6       *string // Tag 23
+	CallVariable7       *string // Tag 24
+	CallVariable8       *string // Tag 25
+	CallVariable9       *string // Tag 26
+	CallVariable10      *string // Tag 27
+	CallWrapupData      *string // Tag 30
+
+	NamedVariables map[string]string   // Tag 82 (ECC variables)
+	NamedArrays    map[string][]string // Tag 83 (ECC arrays)
+}
+
+// optionalString pairs an optional string field with its floating tag.
+type optionalString struct {
+	tag uint16
+	val **string
+}
+
+// stringFields returns the optional string fields of the update in encoding order.
+func (u *CallDataUpdate) stringFields() []optionalString {
+	return []optionalString{
+		{protocol.TagANI, &u.ANI},
+		{protocol.TagUserToUserInfo, &u.UserToUserInfo},
+		{protocol.TagDNIS, &u.DNIS},
+		{protocol.TagDialedNumber, &u.DialedNumber},
+		{protocol.TagCallerEnteredDigits, &u.CallerEnteredDigits},
+		{protocol.TagCallVariable1, &u.CallVariable1},
+		{protocol.TagCallVariable2, &u.CallVariable2},
+		{protocol.TagCallVariable3, &u.CallVariable3},
+		{protocol.TagCallVariable4, &u.CallVariable4},
+		{protocol.TagCallVariable5, &u.CallVariable5},
+		{protocol.TagCallVariable6, &u.CallVariable6},
+		{protocol.TagCallVariable7, &u.CallVariable7},
+		{protocol.TagCallVariable8, &u.CallVariable8},
+		{protocol.TagCallVariable9, &u.CallVariable9},
+		{protocol.TagCallVariable10, &u.CallVariable10},
+		{protocol.TagCallWrapupData, &u.CallWrapupData},
+	}
+}
+
+// SetCallDataReq is sent to update call variables, wrap-up data and ECC
+// variables on a call.
+// Protocol Version 24 - SET_CALL_DATA_REQ (MessageType = 26)
+type SetCallDataReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	// NumNamedVariables and NumNamedArrays (USHORT) are derived from
+	// NamedVariables and NamedArrays when encoding.
+	CallType               uint16 // Type of call (USHORT)
+	CalledPartyDisposition uint16 // Called party disposition (USHORT)
+	CampaignID             uint32 // Campaign ID (UINT)
+	QueryRuleID            uint32 // Query rule ID (UINT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	CallDataUpdate
+}
+
+func (m *SetCallDataReq) Type() uint32 {
+	return protocol.MsgTypeSetCallDataReq
+}
+
+func (m *SetCallDataReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
+	w.WriteUint16(m.CallType)
+	w.WriteUint16(m.CalledPartyDisposition)
+	w.WriteUint32(m.CampaignID)
+	w.WriteUint32(m.QueryRuleID)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	for _, f := range m.stringFields() {
+		if *f.val != nil {
+			fw.WriteString(f.tag, **f.val)
+		}
+	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SetCallDataReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	r.ReadUint16() // NumNamedVariables
+	r.ReadUint16() // NumNamedArrays
+	m.CallType = r.ReadUint16()
+	m.CalledPartyDisposition = r.ReadUint16()
+	m.CampaignID = r.ReadUint32()
+	m.QueryRuleID = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		for _, f := range m.stringFields() {
+			if ff.Has(f.tag) {
+				s := ff.GetString(f.tag)
+				*f.val = &s
+			}
+		}
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
+	}
+
+	return nil
+}
+
+// SetCallDataConf confirms a SET_CALL_DATA_REQ.
+// Protocol Version 24 - SET_CALL_DATA_CONF (MessageType = 27)
+type SetCallDataConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches SetCallDataReq InvokeID (UINT)
+}
+
+func (m *SetCallDataConf) Type() uint32 {
+	return protocol.MsgTypeSetCallDataConf
+}
+
+func (m *SetCallDataConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *SetCallDataConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SetCallDataConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// SendDTMFSignalReq is sent to play DTMF tones on a call, e.g. to drive an
+// external IVR.
+// Protocol Version 24 - SEND_DTMF_SIGNAL_REQ (MessageType = 91)
+type SendDTMFSignalReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	ToneDuration           uint32 // Tone duration in milliseconds (UINT)
+	PauseDuration          uint32 // Pause between tones in milliseconds (UINT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	DTMFString         string // Tag 67 (max 32 bytes)
+	AgentInstrument    string // Tag 5 (max 64 bytes)
+}
+
+func (m *SendDTMFSignalReq) Type() uint32 {
+	return protocol.MsgTypeSendDTMFSignalReq
+}
+
+func (m *SendDTMFSignalReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint32(m.ToneDuration)
+	w.WriteUint32(m.PauseDuration)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.DTMFString != "" {
+		fw.WriteString(protocol.TagDTMFString, m.DTMFString)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SendDTMFSignalReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.ToneDuration = r.ReadUint32()
+	m.PauseDuration = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.DTMFString = ff.GetString(protocol.TagDTMFString)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// SendDTMFSignalConf is the server's response to SendDTMFSignalReq.
+// Protocol Version 24 - SEND_DTMF_SIGNAL_CONF (MessageType = 92)
+type SendDTMFSignalConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches SendDTMFSignalReq InvokeID (UINT)
+}
+
+func (m *SendDTMFSignalConf) Type() uint32 {
+	return protocol.MsgTypeSendDTMFSignalConf
+}
+
+func (m *SendDTMFSignalConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *SendDTMFSignalConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SendDTMFSignalConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// BadCallReq is sent to flag a call as having poor line quality so it can be
+// traced for network troubleshooting.
+// Protocol Version 24 - BAD_CALL_REQ (MessageType = 139)
+type BadCallReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Connection device type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	AgentInstrument    string // Tag 5
+}
+
+func (m *BadCallReq) Type() uint32 {
+	return protocol.MsgTypeBadCallReq
+}
+
+func (m *BadCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *BadCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// BadCallConf is the server's response to BadCallReq.
+// Protocol Version 24 - BAD_CALL_CONF (MessageType = 140)
+type BadCallConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches BadCallReq InvokeID (UINT)
+}
+
+func (m *BadCallConf) Type() uint32 {
+	return protocol.MsgTypeBadCallConf
+}
+
+func (m *BadCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *BadCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	return r.Error()
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *BadCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
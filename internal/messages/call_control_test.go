@@ -0,0 +1,158 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"testing"
+)
+
+func TestCallControlRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&MakeCallReq{
+			InvokeID:          5,
+			PeripheralID:      5000,
+			CallPlacementType: 1,
+			CallMannerType:    1,
+			AlertRings:        4,
+			CallOption:        1,
+			FacilityType:      1,
+			AnsweringMachine:  1,
+			Priority:          true,
+			PostRoute:         true,
+			AgentInstrument:   "4001",
+			DialedNumber:      "5551234",
+			AuthorizationCode: "auth",
+			AccountCode:       "acct",
+			UserToUserInfo:    "uui",
+			CallVariable1:     "v1",
+			CallVariable10:    "v10",
+			CallWrapupData:    "wrap",
+			NamedVariables:    map[string]string{"user.account": "42"},
+			NamedArrays:       map[string][]string{"user.items": {"a", "b"}},
+		},
+		&MakeCallConf{
+			InvokeID:                5,
+			NewConnectionCallID:     100,
+			NewConnectionDeviceType: 1,
+			LineHandle:              2,
+			LineType:                3,
+			NewConnectionDeviceID:   "4001",
+		},
+		&AnswerCallReq{
+			InvokeID:               6,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ConnectionDeviceID:     "4001",
+			AgentInstrument:        "4001",
+		},
+		&AnswerCallConf{InvokeID: 6},
+		&ClearCallReq{
+			InvokeID:               7,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ConnectionDeviceID:     "4001",
+			AgentInstrument:        "4001",
+		},
+		&ClearCallConf{InvokeID: 7},
+		&ClearConnectionReq{
+			InvokeID:               8,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ConnectionDeviceID:     "4001",
+			AgentInstrument:        "4001",
+		},
+		&ClearConnectionConf{InvokeID: 8},
+		&AlternateCallReq{
+			InvokeID:                 9,
+			PeripheralID:             5000,
+			ActiveConnectionCallID:   100,
+			ActiveConnectionType:     1,
+			HeldConnectionCallID:     101,
+			HeldConnectionType:       1,
+			ActiveConnectionDeviceID: "4001",
+			HeldConnectionDeviceID:   "4001",
+		},
+		&AlternateCallConf{InvokeID: 9},
+		&ReconnectCallReq{
+			InvokeID:                 10,
+			PeripheralID:             5000,
+			ActiveConnectionCallID:   100,
+			ActiveConnectionType:     1,
+			HeldConnectionCallID:     101,
+			HeldConnectionType:       1,
+			ActiveConnectionDeviceID: "4001",
+			HeldConnectionDeviceID:   "4001",
+		},
+		&ReconnectCallConf{InvokeID: 10},
+		&SetCallDataReq{
+			InvokeID:               11,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			CallType:               2,
+			CalledPartyDisposition: 3,
+			CampaignID:             4,
+			QueryRuleID:            5,
+			ConnectionDeviceID:     "4001",
+			CallDataUpdate: CallDataUpdate{
+				ANI:            strPtr("5550000"),
+				CallVariable1:  strPtr("v1"),
+				CallVariable10: strPtr(""), // clears the variable
+				NamedVariables: map[string]string{"user.account": "42"},
+				NamedArrays:    map[string][]string{"user.items": {"a", "b"}},
+			},
+		},
+		&SetCallDataConf{InvokeID: 11},
+		&SendDTMFSignalReq{
+			InvokeID:               12,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ToneDuration:           100,
+			PauseDuration:          50,
+			ConnectionDeviceID:     "4001",
+			DTMFString:             "1234#",
+			AgentInstrument:        "4001",
+		},
+		&SendDTMFSignalConf{InvokeID: 12},
+		&BadCallReq{
+			InvokeID:               13,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ConnectionDeviceID:     "4001",
+			AgentInstrument:        "4001",
+		},
+		&BadCallConf{InvokeID: 13},
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// The named variable and array counts are derived from the maps, one per
+// array element.
+func TestSetCallDataReqEncodesNamedCounts(t *testing.T) {
+	data, err := (&SetCallDataReq{
+		InvokeID: 11,
+		CallDataUpdate: CallDataUpdate{
+			NamedVariables: map[string]string{"user.account": "42", "user.lang": "en"},
+			NamedArrays:    map[string][]string{"user.items": {"a", "b", "c"}},
+		},
+	}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := protocol.NewFixedFieldReader(data)
+	r.ReadUint32() // InvokeID
+	r.ReadUint32() // PeripheralID
+	r.ReadUint32() // ConnectionCallID
+	r.ReadUint16() // ConnectionDeviceIDType
+	if vars, arrays := r.ReadUint16(), r.ReadUint16(); vars != 2 || arrays != 3 {
+		t.Errorf("encoded NumNamedVariables %d, NumNamedArrays %d; want 2 and 3", vars, arrays)
+	}
+}
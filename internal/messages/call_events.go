@@ -40,6 +40,10 @@ type BeginCallEvent struct {
 	RouterCallKeyDay     uint32 // Tag 72
 	RouterCallKeyCallID  uint32 // Tag 73
 	RouterCallKeySeqNum  uint32 // Tag 214
+
+	// ECC variables
+	NamedVariables map[string]string   // Tag 82
+	NamedArrays    map[string][]string // Tag 83
 }
 
 func (m *BeginCallEvent) Type() uint32 {
@@ -52,8 +56,8 @@ func (m *BeginCallEvent) Encode() ([]byte, error) {
 	w.WriteUint32(m.PeripheralID)
 	w.WriteUint16(m.PeripheralType)
 	w.WriteUint16(m.NumCTIClients)
-	w.WriteUint16(m.NumNamedVariables)
-	w.WriteUint16(m.NumNamedArrays)
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
 	w.WriteUint16(m.CallType)
 	w.WriteUint16(m.ConnectionDeviceIDType)
 	w.WriteUint32(m.ConnectionCallID)
@@ -116,6 +120,8 @@ func (m *BeginCallEvent) Encode() ([]byte, error) {
 	if m.CallVariable10 != "" {
 		fw.WriteString(protocol.TagCallVariable10, m.CallVariable10)
 	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
 
 	fixed := w.Bytes()
 	floating := fw.Bytes()
@@ -166,6 +172,8 @@ func (m *BeginCallEvent) Decode(data []byte) error {
 		m.CallVariable8 = ff.GetString(protocol.TagCallVariable8)
 		m.CallVariable9 = ff.GetString(protocol.TagCallVariable9)
 		m.CallVariable10 = ff.GetString(protocol.TagCallVariable10)
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
 		m.RouterCallKeyDay = ff.GetUint32(protocol.TagRouterCallKeyDay)
 		m.RouterCallKeyCallID = ff.GetUint32(protocol.TagRouterCallKeyCallID)
 		m.RouterCallKeySeqNum = ff.GetUint32(protocol.TagRouterCallKeySeqNum)
@@ -275,6 +283,10 @@ type CallDeliveredEvent struct {
 	CallVariable9        string
 	CallVariable10       string
 	CallWrapupData       string
+
+	// ECC variables
+	NamedVariables map[string]string   // Tag 82
+	NamedArrays    map[string][]string // Tag 83
 }
 
 func (m *CallDeliveredEvent) Type() uint32 {
@@ -301,9 +313,97 @@ func (m *CallDeliveredEvent) Encode() ([]byte, error) {
 	w.WriteUint16(m.LastRedirectDeviceType)
 	w.WriteUint16(m.LocalConnectionState)
 	w.WriteUint16(m.EventCause)
-	w.WriteUint16(m.NumNamedVariables)
-	w.WriteUint16(m.NumNamedArrays)
-	return w.Bytes(), w.Error()
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.AlertingDeviceID != "" {
+		fw.WriteString(protocol.TagAlertingDeviceID, m.AlertingDeviceID)
+	}
+	if m.CallingDeviceID != "" {
+		fw.WriteString(protocol.TagCallingDeviceID, m.CallingDeviceID)
+	}
+	if m.CalledDeviceID != "" {
+		fw.WriteString(protocol.TagCalledDeviceID, m.CalledDeviceID)
+	}
+	if m.LastRedirectDeviceID != "" {
+		fw.WriteString(protocol.TagLastRedirectDeviceID, m.LastRedirectDeviceID)
+	}
+	if m.TrunkNumber != 0 {
+		fw.WriteUint32(protocol.TagTrunkNumber, m.TrunkNumber)
+	}
+	if m.TrunkGroupNumber != 0 {
+		fw.WriteUint32(protocol.TagTrunkGroupNumber, m.TrunkGroupNumber)
+	}
+	if m.SecondaryConnCallID != 0 {
+		fw.WriteUint32(protocol.TagSecondaryConnCallID, m.SecondaryConnCallID)
+	}
+	if m.ANI != "" {
+		fw.WriteString(protocol.TagANI, m.ANI)
+	}
+	if m.DNIS != "" {
+		fw.WriteString(protocol.TagDNIS, m.DNIS)
+	}
+	if m.DialedNumber != "" {
+		fw.WriteString(protocol.TagDialedNumber, m.DialedNumber)
+	}
+	if m.CallerEnteredDigits != "" {
+		fw.WriteString(protocol.TagCallerEnteredDigits, m.CallerEnteredDigits)
+	}
+	if m.UserToUserInfo != "" {
+		fw.WriteString(protocol.TagUserToUserInfo, m.UserToUserInfo)
+	}
+	if m.CallVariable1 != "" {
+		fw.WriteString(protocol.TagCallVariable1, m.CallVariable1)
+	}
+	if m.CallVariable2 != "" {
+		fw.WriteString(protocol.TagCallVariable2, m.CallVariable2)
+	}
+	if m.CallVariable3 != "" {
+		fw.WriteString(protocol.TagCallVariable3, m.CallVariable3)
+	}
+	if m.CallVariable4 != "" {
+		fw.WriteString(protocol.TagCallVariable4, m.CallVariable4)
+	}
+	if m.CallVariable5 != "" {
+		fw.WriteString(protocol.TagCallVariable5, m.CallVariable5)
+	}
+	if m.CallVariable6 != "" {
+		fw.WriteString(protocol.TagCallVariable6, m.CallVariable6)
+	}
+	if m.CallVariable7 != "" {
+		fw.WriteString(protocol.TagCallVariable7, m.CallVariable7)
+	}
+	if m.CallVariable8 != "" {
+		fw.WriteString(protocol.TagCallVariable8, m.CallVariable8)
+	}
+	if m.CallVariable9 != "" {
+		fw.WriteString(protocol.TagCallVariable9, m.CallVariable9)
+	}
+	if m.CallVariable10 != "" {
+		fw.WriteString(protocol.TagCallVariable10, m.CallVariable10)
+	}
+	if m.CallWrapupData != "" {
+		fw.WriteString(protocol.TagCallWrapupData, m.CallWrapupData)
+	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
 }
 
 func (m *CallDeliveredEvent) Decode(data []byte) error {
@@ -362,6 +462,8 @@ func (m *CallDeliveredEvent) Decode(data []byte) error {
 		m.CallVariable9 = ff.GetString(protocol.TagCallVariable9)
 		m.CallVariable10 = ff.GetString(protocol.TagCallVariable10)
 		m.CallWrapupData = ff.GetString(protocol.TagCallWrapupData)
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
 	}
 
 	return nil
@@ -1234,6 +1336,102 @@ func (m *CallTransferredEvent) Decode(data []byte) error {
 	return nil
 }
 
+// CallDivertedEvent is sent when a call is removed from a device before being
+// answered, e.g. by call forwarding or deflection.
+// Protocol Version 24 - CALL_DIVERTED_EVENT (MessageType = 19)
+type CallDivertedEvent struct {
+	// Fixed Part
+	MonitorID              uint32 // Monitor ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	PeripheralType         uint16 // Peripheral type (USHORT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ServiceNumber          uint32 // Service number (UINT)
+	ServiceID              uint32 // Service ID (UINT)
+	DivertingDeviceType    uint16 // Diverting device type (USHORT)
+	CalledDeviceType       uint16 // Called device type (USHORT)
+	LocalConnectionState   uint16 // Local connection state (USHORT)
+	EventCause             uint16 // Event cause (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	DivertingDeviceID  string // Tag 219 - Device the call was diverted from
+	CalledDeviceID     string // Tag 13 - Device the call was diverted to
+}
+
+func (m *CallDivertedEvent) Type() uint32 {
+	return protocol.MsgTypeCallDivertedEvent
+}
+
+func (m *CallDivertedEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.MonitorID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(m.PeripheralType)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint32(m.ServiceNumber)
+	w.WriteUint32(m.ServiceID)
+	w.WriteUint16(m.DivertingDeviceType)
+	w.WriteUint16(m.CalledDeviceType)
+	w.WriteUint16(m.LocalConnectionState)
+	w.WriteUint16(m.EventCause)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.DivertingDeviceID != "" {
+		fw.WriteString(protocol.TagDivertingDeviceID, m.DivertingDeviceID)
+	}
+	if m.CalledDeviceID != "" {
+		fw.WriteString(protocol.TagCalledDeviceID, m.CalledDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *CallDivertedEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.MonitorID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.PeripheralType = r.ReadUint16()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ServiceNumber = r.ReadUint32()
+	m.ServiceID = r.ReadUint32()
+	m.DivertingDeviceType = r.ReadUint16()
+	m.CalledDeviceType = r.ReadUint16()
+	m.LocalConnectionState = r.ReadUint16()
+	m.EventCause = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.DivertingDeviceID = ff.GetString(protocol.TagDivertingDeviceID)
+		m.CalledDeviceID = ff.GetString(protocol.TagCalledDeviceID)
+	}
+
+	return nil
+}
+
 // CallQueuedEvent is sent when a call is queued.
 type CallQueuedEvent struct {
 	MonitorID              uint32
@@ -1383,6 +1581,10 @@ type CallDataUpdateEvent struct {
 	RouterCallKeyDay      uint32 // Tag 72
 	RouterCallKeyCallID   uint32 // Tag 73
 	RouterCallKeySeqNum   uint32 // Tag 214
+
+	// ECC variables
+	NamedVariables map[string]string   // Tag 82
+	NamedArrays    map[string][]string // Tag 83
 }
 
 func (m *CallDataUpdateEvent) Type() uint32 {
@@ -1395,8 +1597,8 @@ func (m *CallDataUpdateEvent) Encode() ([]byte, error) {
 	w.WriteUint32(m.PeripheralID)
 	w.WriteUint16(m.PeripheralType)
 	w.WriteUint16(m.NumCTIClients)
-	w.WriteUint16(m.NumNamedVariables)
-	w.WriteUint16(m.NumNamedArrays)
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
 	w.WriteUint16(m.CallType)
 	w.WriteUint16(m.ConnectionDeviceIDType)
 	w.WriteUint32(m.ConnectionCallID)
@@ -1466,6 +1668,8 @@ func (m *CallDataUpdateEvent) Encode() ([]byte, error) {
 	if m.CallVariable10 != "" {
 		fw.WriteString(protocol.TagCallVariable10, m.CallVariable10)
 	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
 
 	fixed := w.Bytes()
 	floating := fw.Bytes()
@@ -1521,6 +1725,8 @@ func (m *CallDataUpdateEvent) Decode(data []byte) error {
 		m.CallVariable8 = ff.GetString(protocol.TagCallVariable8)
 		m.CallVariable9 = ff.GetString(protocol.TagCallVariable9)
 		m.CallVariable10 = ff.GetString(protocol.TagCallVariable10)
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
 		m.RouterCallKeyDay = ff.GetUint32(protocol.TagRouterCallKeyDay)
 		m.RouterCallKeyCallID = ff.GetUint32(protocol.TagRouterCallKeyCallID)
 		m.RouterCallKeySeqNum = ff.GetUint32(protocol.TagRouterCallKeySeqNum)
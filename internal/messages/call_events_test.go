@@ -0,0 +1,62 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestCallDivertedEventRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&CallDivertedEvent{
+			MonitorID:              1,
+			PeripheralID:           5000,
+			PeripheralType:         1,
+			ConnectionDeviceIDType: 1,
+			ConnectionCallID:       100,
+			ServiceNumber:          60,
+			ServiceID:              6000,
+			DivertingDeviceType:    1,
+			CalledDeviceType:       1,
+			LocalConnectionState:   2,
+			EventCause:             3,
+			ConnectionDeviceID:     "4001",
+			DivertingDeviceID:      "4001",
+			CalledDeviceID:         "4002",
+		},
+	})
+}
+
+// TestNamedVariablesRoundTrip checks the ECC data of the call events that
+// carry it, and that the named variable and array counts are derived from it.
+func TestNamedVariablesRoundTrip(t *testing.T) {
+	vars := map[string]string{"user.account": "42", "user.lang": "en"}
+	arrays := map[string][]string{"user.items": {"a", "b", "c"}}
+
+	tests := []protocol.Message{
+		&BeginCallEvent{ConnectionCallID: 100, ConnectionDeviceID: "4001", NamedVariables: vars, NamedArrays: arrays},
+		&CallDeliveredEvent{ConnectionCallID: 100, ConnectionDeviceID: "4001", NamedVariables: vars, NamedArrays: arrays},
+		&CallDataUpdateEvent{ConnectionCallID: 100, ConnectionDeviceID: "4001", NamedVariables: vars, NamedArrays: arrays},
+		&AgentPreCallEvent{ConnectionCallID: 100, ConnectionDeviceID: "4001", NamedVariables: vars, NamedArrays: arrays},
+	}
+
+	for _, msg := range tests {
+		got := reflect.ValueOf(roundTrip(t, msg)).Elem()
+
+		if v := got.FieldByName("NamedVariables").Interface(); !reflect.DeepEqual(v, vars) {
+			t.Errorf("%T NamedVariables = %v, want %v", msg, v, vars)
+		}
+		if v := got.FieldByName("NamedArrays").Interface(); !reflect.DeepEqual(v, arrays) {
+			t.Errorf("%T NamedArrays = %v, want %v", msg, v, arrays)
+		}
+		if n := got.FieldByName("NumNamedVariables").Uint(); n != uint64(len(vars)) {
+			t.Errorf("%T NumNamedVariables = %d, want %d", msg, n, len(vars))
+		}
+		if n := got.FieldByName("NumNamedArrays").Uint(); n != 3 {
+			t.Errorf("%T NumNamedArrays = %d, want 3 (one per element)", msg, n)
+		}
+		if id := got.FieldByName("ConnectionDeviceID").String(); id != "4001" {
+			t.Errorf("%T ConnectionDeviceID = %q, want %q", msg, id, "4001")
+		}
+	}
+}
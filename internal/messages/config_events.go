@@ -29,14 +29,17 @@ func ConfigOperationName(op ConfigOperation) string {
 
 // AgentConfigRecord represents a single agent configuration record.
 type AgentConfigRecord struct {
-	RecordType    uint16 // Record type (Tag 183)
-	AgentType     uint16 // Agent type (Tag 189)
-	LoginID       string // Login ID (Tag 190)
-	LastName      string // Last name (Tag 138)
-	FirstName     string // First name (Tag 137)
-	Extension     string // Extension (Tag 173/3)
-	NumCSQ        uint16 // Number of CSQs (Tag 191)
-	CSQIDs        []uint32 // CSQ IDs (Tag 62, repeated)
+	AgentID      string   // Agent ID (Tag 4), starts each record
+	RecordType   uint16   // Record type (Tag 183)
+	AgentType    uint16   // Agent type (Tag 189)
+	LoginID      string   // Login ID (Tag 190)
+	LastName     string   // Last name (Tag 138)
+	FirstName    string   // First name (Tag 137)
+	Extension    string   // Extension (Tag 3)
+	SkillGroupID uint32   // Skill group ID (Tag 10)
+	ICMAgentID   int32    // ICM agent ID (Tag 220)
+	NumCSQ       uint16   // Number of CSQs (Tag 191)
+	CSQIDs       []uint32 // CSQ IDs (Tag 62, repeated NumCSQ times)
 }
 
 // ConfigAgentEvent is sent when agent configuration changes.
@@ -48,14 +51,8 @@ type ConfigAgentEvent struct {
 	ConfigOperation uint16 // Configuration operation (USHORT)
 	NumRecords      uint16 // Number of records (USHORT)
 
-	// Floating fields contain agent records
-	AgentID       string // Tag 4 - Agent ID
-	AgentExtension string // Tag 3 - Agent extension
-	LoginID       string // Tag 190 - Login ID
-	LastName      string // Tag 138 - Last name
-	FirstName     string // Tag 137 - First name
-	SkillGroupID  uint32 // Tag 10 - Skill group ID
-	ICMAgentID    uint32 // ICM Agent ID from fixed fields or floating
+	// Records contains repeating agent info (up to NumRecords)
+	Records []AgentConfigRecord
 }
 
 func (m *ConfigAgentEvent) Type() uint32 {
@@ -66,30 +63,28 @@ func (m *ConfigAgentEvent) Encode() ([]byte, error) {
 	w := protocol.NewFixedFieldWriter()
 	w.WriteUint32(m.PeripheralID)
 	w.WriteUint16(m.ConfigOperation)
-	w.WriteUint16(m.NumRecords)
+	w.WriteUint16(uint16(len(m.Records)))
 
 	if err := w.Error(); err != nil {
 		return nil, err
 	}
 
+	// Write AgentConfigRecord repeated fields, each record followed by its CSQ IDs
 	fw := protocol.NewFloatingFieldWriter()
-	if m.AgentID != "" {
-		fw.WriteString(protocol.TagAgentID, m.AgentID)
-	}
-	if m.AgentExtension != "" {
-		fw.WriteString(protocol.TagAgentExtension, m.AgentExtension)
-	}
-	if m.LoginID != "" {
-		fw.WriteString(protocol.TagLoginID, m.LoginID)
-	}
-	if m.LastName != "" {
-		fw.WriteString(protocol.TagLastName, m.LastName)
-	}
-	if m.FirstName != "" {
-		fw.WriteString(protocol.TagFirstName, m.FirstName)
-	}
-	if m.SkillGroupID != 0 {
-		fw.WriteUint32(protocol.TagSkillGroupID, m.SkillGroupID)
+	for _, rec := range m.Records {
+		fw.WriteString(protocol.TagAgentID, rec.AgentID)
+		fw.WriteUint16(protocol.TagRecordType, rec.RecordType)
+		fw.WriteUint16(protocol.TagAgentType, rec.AgentType)
+		fw.WriteString(protocol.TagLoginID, rec.LoginID)
+		fw.WriteString(protocol.TagLastName, rec.LastName)
+		fw.WriteString(protocol.TagFirstName, rec.FirstName)
+		fw.WriteString(protocol.TagAgentExtension, rec.Extension)
+		fw.WriteUint32(protocol.TagSkillGroupID, rec.SkillGroupID)
+		fw.WriteUint32(protocol.TagICMAgentID, uint32(rec.ICMAgentID))
+		fw.WriteUint16(protocol.TagNumCSQ, uint16(len(rec.CSQIDs)))
+		for _, csqID := range rec.CSQIDs {
+			fw.WriteUint32(protocol.TagCSQID, csqID)
+		}
 	}
 
 	fixed := w.Bytes()
@@ -112,16 +107,46 @@ func (m *ConfigAgentEvent) Decode(data []byte) error {
 	}
 
 	if r.Remaining() > 0 {
-		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
-		if err != nil {
-			return err
+		// Fields are walked in order since optional fields may be absent
+		// from some records; each Agent ID starts a new record.
+		p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+		for p.HasMore() {
+			tag, data, err := p.Next()
+			if err != nil {
+				return err
+			}
+			if tag == protocol.TagAgentID {
+				m.Records = append(m.Records, AgentConfigRecord{AgentID: protocol.FieldString(data)})
+				continue
+			}
+			if len(m.Records) == 0 {
+				continue
+			}
+
+			rec := &m.Records[len(m.Records)-1]
+			switch tag {
+			case protocol.TagRecordType:
+				rec.RecordType = protocol.FieldUint16(data)
+			case protocol.TagAgentType:
+				rec.AgentType = protocol.FieldUint16(data)
+			case protocol.TagLoginID:
+				rec.LoginID = protocol.FieldString(data)
+			case protocol.TagLastName:
+				rec.LastName = protocol.FieldString(data)
+			case protocol.TagFirstName:
+				rec.FirstName = protocol.FieldString(data)
+			case protocol.TagAgentExtension:
+				rec.Extension = protocol.FieldString(data)
+			case protocol.TagSkillGroupID:
+				rec.SkillGroupID = protocol.FieldUint32(data)
+			case protocol.TagICMAgentID:
+				rec.ICMAgentID = int32(protocol.FieldUint32(data))
+			case protocol.TagNumCSQ:
+				rec.NumCSQ = protocol.FieldUint16(data)
+			case protocol.TagCSQID:
+				rec.CSQIDs = append(rec.CSQIDs, protocol.FieldUint32(data))
+			}
 		}
-		m.AgentID = ff.GetString(protocol.TagAgentID)
-		m.AgentExtension = ff.GetString(protocol.TagAgentExtension)
-		m.LoginID = ff.GetString(protocol.TagLoginID)
-		m.LastName = ff.GetString(protocol.TagLastName)
-		m.FirstName = ff.GetString(protocol.TagFirstName)
-		m.SkillGroupID = ff.GetUint32(protocol.TagSkillGroupID)
 	}
 
 	return nil
@@ -132,6 +157,15 @@ func (m *ConfigAgentEvent) OperationName() string {
 	return ConfigOperationName(ConfigOperation(m.ConfigOperation))
 }
 
+// DeviceConfigRecord represents a single device configuration record.
+type DeviceConfigRecord struct {
+	DeviceID     string // Device identifier (Tag 5), starts each record
+	DeviceType   uint16 // Device type (Tag 213)
+	Extension    string // Extension (Tag 3)
+	SkillGroupID uint32 // Associated skill group ID (Tag 10)
+	ServiceID    uint32 // Associated service ID (Tag 8)
+}
+
 // ConfigDeviceEvent is sent when device configuration changes.
 // Contains information about device additions, updates, or deletions.
 // Protocol Version 24 - CONFIG_DEVICE_EVENT (MessageType = 238)
@@ -141,12 +175,8 @@ type ConfigDeviceEvent struct {
 	ConfigOperation uint16 // Configuration operation (USHORT)
 	NumRecords      uint16 // Number of records (USHORT)
 
-	// Floating fields contain device records
-	DeviceID        string // Device identifier
-	DeviceType      uint16 // Device type
-	Extension       string // Extension (Tag 3)
-	SkillGroupID    uint32 // Tag 10 - Associated skill group ID
-	ServiceID       uint32 // Tag 8 - Associated service ID
+	// Records contains repeating device info (up to NumRecords)
+	Records []DeviceConfigRecord
 }
 
 func (m *ConfigDeviceEvent) Type() uint32 {
@@ -157,21 +187,20 @@ func (m *ConfigDeviceEvent) Encode() ([]byte, error) {
 	w := protocol.NewFixedFieldWriter()
 	w.WriteUint32(m.PeripheralID)
 	w.WriteUint16(m.ConfigOperation)
-	w.WriteUint16(m.NumRecords)
+	w.WriteUint16(uint16(len(m.Records)))
 
 	if err := w.Error(); err != nil {
 		return nil, err
 	}
 
+	// Write DeviceConfigRecord repeated fields
 	fw := protocol.NewFloatingFieldWriter()
-	if m.Extension != "" {
-		fw.WriteString(protocol.TagAgentExtension, m.Extension)
-	}
-	if m.SkillGroupID != 0 {
-		fw.WriteUint32(protocol.TagSkillGroupID, m.SkillGroupID)
-	}
-	if m.ServiceID != 0 {
-		fw.WriteUint32(protocol.TagServiceID, m.ServiceID)
+	for _, rec := range m.Records {
+		fw.WriteString(protocol.TagAgentInstrument, rec.DeviceID)
+		fw.WriteUint16(protocol.TagDeviceType, rec.DeviceType)
+		fw.WriteString(protocol.TagAgentExtension, rec.Extension)
+		fw.WriteUint32(protocol.TagSkillGroupID, rec.SkillGroupID)
+		fw.WriteUint32(protocol.TagServiceID, rec.ServiceID)
 	}
 
 	fixed := w.Bytes()
@@ -194,13 +223,33 @@ func (m *ConfigDeviceEvent) Decode(data []byte) error {
 	}
 
 	if r.Remaining() > 0 {
-		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
-		if err != nil {
-			return err
+		// Each device identifier starts a new record
+		p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+		for p.HasMore() {
+			tag, data, err := p.Next()
+			if err != nil {
+				return err
+			}
+			if tag == protocol.TagAgentInstrument {
+				m.Records = append(m.Records, DeviceConfigRecord{DeviceID: protocol.FieldString(data)})
+				continue
+			}
+			if len(m.Records) == 0 {
+				continue
+			}
+
+			rec := &m.Records[len(m.Records)-1]
+			switch tag {
+			case protocol.TagDeviceType:
+				rec.DeviceType = protocol.FieldUint16(data)
+			case protocol.TagAgentExtension:
+				rec.Extension = protocol.FieldString(data)
+			case protocol.TagSkillGroupID:
+				rec.SkillGroupID = protocol.FieldUint32(data)
+			case protocol.TagServiceID:
+				rec.ServiceID = protocol.FieldUint32(data)
+			}
 		}
-		m.Extension = ff.GetString(protocol.TagAgentExtension)
-		m.SkillGroupID = ff.GetUint32(protocol.TagSkillGroupID)
-		m.ServiceID = ff.GetUint32(protocol.TagServiceID)
 	}
 
 	return nil
@@ -211,6 +260,15 @@ func (m *ConfigDeviceEvent) OperationName() string {
 	return ConfigOperationName(ConfigOperation(m.ConfigOperation))
 }
 
+// CSQConfigRecord represents a single CSQ configuration record.
+type CSQConfigRecord struct {
+	CSQID            uint32 // CSQ ID (Tag 62), starts each record
+	SkillGroupID     uint32 // Skill group ID (Tag 10)
+	SkillGroupNumber uint32 // Skill group number (Tag 9)
+	ServiceID        uint32 // Service ID (Tag 8)
+	ServiceNumber    uint32 // Service number (Tag 7)
+}
+
 // ConfigCSQEvent is sent when CSQ (Contact Service Queue) configuration changes.
 // Protocol Version 24 - CONFIG_CSQ_EVENT (MessageType = 236)
 type ConfigCSQEvent struct {
@@ -219,12 +277,8 @@ type ConfigCSQEvent struct {
 	ConfigOperation uint16 // Configuration operation (USHORT)
 	NumRecords      uint16 // Number of records (USHORT)
 
-	// Floating fields
-	CSQID           uint32 // Tag 62 - CSQ ID
-	SkillGroupID    uint32 // Tag 10 - Skill group ID
-	SkillGroupNumber uint32 // Tag 9 - Skill group number
-	ServiceID       uint32 // Tag 8 - Service ID
-	ServiceNumber   uint32 // Tag 7 - Service number
+	// Records contains repeating CSQ info (up to NumRecords)
+	Records []CSQConfigRecord
 }
 
 func (m *ConfigCSQEvent) Type() uint32 {
@@ -235,27 +289,20 @@ func (m *ConfigCSQEvent) Encode() ([]byte, error) {
 	w := protocol.NewFixedFieldWriter()
 	w.WriteUint32(m.PeripheralID)
 	w.WriteUint16(m.ConfigOperation)
-	w.WriteUint16(m.NumRecords)
+	w.WriteUint16(uint16(len(m.Records)))
 
 	if err := w.Error(); err != nil {
 		return nil, err
 	}
 
+	// Write CSQConfigRecord repeated fields
 	fw := protocol.NewFloatingFieldWriter()
-	if m.CSQID != 0 {
-		fw.WriteUint32(protocol.TagCSQID, m.CSQID)
-	}
-	if m.SkillGroupID != 0 {
-		fw.WriteUint32(protocol.TagSkillGroupID, m.SkillGroupID)
-	}
-	if m.SkillGroupNumber != 0 {
-		fw.WriteUint32(protocol.TagSkillGroupNumber, m.SkillGroupNumber)
-	}
-	if m.ServiceID != 0 {
-		fw.WriteUint32(protocol.TagServiceID, m.ServiceID)
-	}
-	if m.ServiceNumber != 0 {
-		fw.WriteUint32(protocol.TagServiceNumber, m.ServiceNumber)
+	for _, rec := range m.Records {
+		fw.WriteUint32(protocol.TagCSQID, rec.CSQID)
+		fw.WriteUint32(protocol.TagSkillGroupID, rec.SkillGroupID)
+		fw.WriteUint32(protocol.TagSkillGroupNumber, rec.SkillGroupNumber)
+		fw.WriteUint32(protocol.TagServiceID, rec.ServiceID)
+		fw.WriteUint32(protocol.TagServiceNumber, rec.ServiceNumber)
 	}
 
 	fixed := w.Bytes()
@@ -278,15 +325,33 @@ func (m *ConfigCSQEvent) Decode(data []byte) error {
 	}
 
 	if r.Remaining() > 0 {
-		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
-		if err != nil {
-			return err
+		// Each CSQ ID starts a new record
+		p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+		for p.HasMore() {
+			tag, data, err := p.Next()
+			if err != nil {
+				return err
+			}
+			if tag == protocol.TagCSQID {
+				m.Records = append(m.Records, CSQConfigRecord{CSQID: protocol.FieldUint32(data)})
+				continue
+			}
+			if len(m.Records) == 0 {
+				continue
+			}
+
+			rec := &m.Records[len(m.Records)-1]
+			switch tag {
+			case protocol.TagSkillGroupID:
+				rec.SkillGroupID = protocol.FieldUint32(data)
+			case protocol.TagSkillGroupNumber:
+				rec.SkillGroupNumber = protocol.FieldUint32(data)
+			case protocol.TagServiceID:
+				rec.ServiceID = protocol.FieldUint32(data)
+			case protocol.TagServiceNumber:
+				rec.ServiceNumber = protocol.FieldUint32(data)
+			}
 		}
-		m.CSQID = ff.GetUint32(protocol.TagCSQID)
-		m.SkillGroupID = ff.GetUint32(protocol.TagSkillGroupID)
-		m.SkillGroupNumber = ff.GetUint32(protocol.TagSkillGroupNumber)
-		m.ServiceID = ff.GetUint32(protocol.TagServiceID)
-		m.ServiceNumber = ff.GetUint32(protocol.TagServiceNumber)
 	}
 
 	return nil
@@ -353,11 +418,15 @@ func (m *ConfigEndEvent) Decode(data []byte) error {
 }
 
 // ConfigRequestEvent is used to request configuration data.
+// When ConfigKey is set, only changes made since that key are sent.
 // Protocol Version 24 - CONFIG_REQUEST_EVENT (MessageType = 232)
 type ConfigRequestEvent struct {
 	// Fixed Part
 	PeripheralID uint32 // Peripheral ID (UINT)
 	ConfigType   uint16 // Type of configuration requested (USHORT)
+
+	// Floating fields
+	ConfigKey []byte // Tag 209 - Key of the last configuration received
 }
 
 func (m *ConfigRequestEvent) Type() uint32 {
@@ -368,12 +437,229 @@ func (m *ConfigRequestEvent) Encode() ([]byte, error) {
 	w := protocol.NewFixedFieldWriter()
 	w.WriteUint32(m.PeripheralID)
 	w.WriteUint16(m.ConfigType)
-	return w.Bytes(), w.Error()
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if len(m.ConfigKey) > 0 {
+		fw.WriteBytes(protocol.TagConfigKey, m.ConfigKey)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
 }
 
 func (m *ConfigRequestEvent) Decode(data []byte) error {
 	r := protocol.NewFixedFieldReader(data)
 	m.PeripheralID = r.ReadUint32()
 	m.ConfigType = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConfigKey = ff.GetBytes(protocol.TagConfigKey)
+	}
+
+	return nil
+}
+
+// ConfigRequestKeyEvent is sent to ask for the key identifying the current
+// configuration. The server answers with CONFIG_KEY_EVENT.
+// Protocol Version 24 - CONFIG_REQUEST_KEY_EVENT (MessageType = 230)
+type ConfigRequestKeyEvent struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+}
+
+func (m *ConfigRequestKeyEvent) Type() uint32 {
+	return protocol.MsgTypeConfigRequestKeyEvent
+}
+
+func (m *ConfigRequestKeyEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *ConfigRequestKeyEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
 	return r.Error()
 }
+
+// ConfigKeyEvent carries the key identifying the current configuration.
+// The key changes whenever the configuration changes.
+// Protocol Version 24 - CONFIG_KEY_EVENT (MessageType = 231)
+type ConfigKeyEvent struct {
+	// Fixed Part
+	InvokeID     uint32 // Matches ConfigRequestKeyEvent InvokeID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+
+	// Floating fields
+	ConfigKey []byte // Tag 209
+}
+
+func (m *ConfigKeyEvent) Type() uint32 {
+	return protocol.MsgTypeConfigKeyEvent
+}
+
+func (m *ConfigKeyEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if len(m.ConfigKey) > 0 {
+		fw.WriteBytes(protocol.TagConfigKey, m.ConfigKey)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *ConfigKeyEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConfigKey = ff.GetBytes(protocol.TagConfigKey)
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the ConfigRequestKeyEvent this answers.
+func (m *ConfigKeyEvent) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// ApplicationConfigRecord represents a single application configuration record.
+type ApplicationConfigRecord struct {
+	ApplicationID   uint32 // Application ID (Tag 210)
+	ApplicationName string // Application name (Tag 211)
+	Enabled         bool   // Application enabled (Tag 212)
+}
+
+// ConfigApplicationEvent is sent when application configuration changes.
+// Protocol Version 24 - CONFIG_APPLICATION_EVENT (MessageType = 235)
+type ConfigApplicationEvent struct {
+	// Fixed Part
+	PeripheralID    uint32 // Peripheral ID (UINT)
+	ConfigOperation uint16 // Configuration operation (USHORT)
+	NumRecords      uint16 // Number of records (USHORT)
+
+	// Records contains repeating application info (up to NumRecords)
+	Records []ApplicationConfigRecord
+}
+
+func (m *ConfigApplicationEvent) Type() uint32 {
+	return protocol.MsgTypeConfigApplicationEvent
+}
+
+func (m *ConfigApplicationEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(m.ConfigOperation)
+	w.WriteUint16(uint16(len(m.Records)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	for _, rec := range m.Records {
+		enabled := uint16(0)
+		if rec.Enabled {
+			enabled = 1
+		}
+		fw.WriteUint32(protocol.TagApplicationID, rec.ApplicationID)
+		fw.WriteString(protocol.TagApplicationName, rec.ApplicationName)
+		fw.WriteUint16(protocol.TagApplicationEnabled, enabled)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *ConfigApplicationEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.PeripheralID = r.ReadUint32()
+	m.ConfigOperation = r.ReadUint16()
+	m.NumRecords = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		// Each application ID starts a new record
+		p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+		for p.HasMore() {
+			tag, data, err := p.Next()
+			if err != nil {
+				return err
+			}
+			if tag == protocol.TagApplicationID {
+				m.Records = append(m.Records, ApplicationConfigRecord{ApplicationID: protocol.FieldUint32(data)})
+				continue
+			}
+			if len(m.Records) == 0 {
+				continue
+			}
+
+			rec := &m.Records[len(m.Records)-1]
+			switch tag {
+			case protocol.TagApplicationName:
+				rec.ApplicationName = protocol.FieldString(data)
+			case protocol.TagApplicationEnabled:
+				rec.Enabled = protocol.FieldUint16(data) != 0
+			}
+		}
+	}
+
+	return nil
+}
+
+// OperationName returns a human-readable name for the config operation.
+func (m *ConfigApplicationEvent) OperationName() string {
+	return ConfigOperationName(ConfigOperation(m.ConfigOperation))
+}
@@ -0,0 +1,161 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestConfigEventsRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&ConfigBeginEvent{PeripheralID: 5000, ConfigType: protocol.ConfigTypeAll},
+		&ConfigEndEvent{PeripheralID: 5000, ConfigType: protocol.ConfigTypeAll, NumRecords: 12},
+		&ConfigRequestEvent{PeripheralID: 5000, ConfigType: protocol.ConfigTypeAll, ConfigKey: []byte{1, 2, 3, 4}},
+		&ConfigRequestKeyEvent{InvokeID: 23, PeripheralID: 5000},
+		&ConfigKeyEvent{InvokeID: 23, PeripheralID: 5000, ConfigKey: []byte{1, 2, 3, 4}},
+		&ConfigApplicationEvent{
+			PeripheralID:    5000,
+			ConfigOperation: uint16(ConfigOperationAdd),
+			NumRecords:      2,
+			Records: []ApplicationConfigRecord{
+				{ApplicationID: 1, ApplicationName: "IVR", Enabled: true},
+				{ApplicationID: 2, ApplicationName: "Survey", Enabled: false},
+			},
+		},
+		&ConfigAgentEvent{
+			PeripheralID:    5000,
+			ConfigOperation: uint16(ConfigOperationUpdate),
+			NumRecords:      2,
+			Records: []AgentConfigRecord{
+				{AgentID: "1001", RecordType: 1, AgentType: 2, LoginID: "jdoe", LastName: "Doe", FirstName: "John",
+					Extension: "4001", SkillGroupID: 5010, ICMAgentID: 5150, NumCSQ: 2, CSQIDs: []uint32{1, 2}},
+				{AgentID: "1002", RecordType: 1, AgentType: 1, LoginID: "asmith", LastName: "Smith", FirstName: "Ann",
+					Extension: "4002", SkillGroupID: 5011, ICMAgentID: 5151, NumCSQ: 1, CSQIDs: []uint32{3}},
+			},
+		},
+		&ConfigDeviceEvent{
+			PeripheralID:    5000,
+			ConfigOperation: uint16(ConfigOperationAdd),
+			NumRecords:      2,
+			Records: []DeviceConfigRecord{
+				{DeviceID: "4001", DeviceType: 1, Extension: "4001", SkillGroupID: 5010, ServiceID: 6000},
+				{DeviceID: "4002", DeviceType: 2, Extension: "4002", SkillGroupID: 5011, ServiceID: 6001},
+			},
+		},
+		&ConfigCSQEvent{
+			PeripheralID:    5000,
+			ConfigOperation: uint16(ConfigOperationDelete),
+			NumRecords:      2,
+			Records: []CSQConfigRecord{
+				{CSQID: 1, SkillGroupID: 5010, SkillGroupNumber: 10, ServiceID: 6000, ServiceNumber: 60},
+				{CSQID: 2, SkillGroupID: 5011, SkillGroupNumber: 11, ServiceID: 6001, ServiceNumber: 61},
+			},
+		},
+	})
+}
+
+// configEventBody builds a config event body with the given floating part.
+func configEventBody(numRecords uint16, fw *protocol.FloatingFieldWriter) []byte {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(5000)
+	w.WriteUint16(uint16(ConfigOperationUpdate))
+	w.WriteUint16(numRecords)
+	return append(w.Bytes(), fw.Bytes()...)
+}
+
+// Records that leave out optional fields must not shift the following
+// records' fields onto them.
+func TestConfigAgentEventDecodeSparseRecords(t *testing.T) {
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteString(protocol.TagAgentID, "1001")
+	fw.WriteString(protocol.TagLastName, "Doe")
+	fw.WriteUint16(protocol.TagNumCSQ, 2)
+	fw.WriteUint32(protocol.TagCSQID, 1)
+	fw.WriteUint32(protocol.TagCSQID, 2)
+	fw.WriteString(protocol.TagAgentID, "1002")
+	fw.WriteString(protocol.TagLoginID, "asmith")
+	fw.WriteUint32(protocol.TagSkillGroupID, 5011)
+	fw.WriteUint32(protocol.TagICMAgentID, 5151)
+	fw.WriteString(protocol.TagAgentID, "1003")
+	fw.WriteUint16(protocol.TagNumCSQ, 1)
+	fw.WriteUint32(protocol.TagCSQID, 3)
+
+	var m ConfigAgentEvent
+	if err := m.Decode(configEventBody(3, fw)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []AgentConfigRecord{
+		{AgentID: "1001", LastName: "Doe", NumCSQ: 2, CSQIDs: []uint32{1, 2}},
+		{AgentID: "1002", LoginID: "asmith", SkillGroupID: 5011, ICMAgentID: 5151},
+		{AgentID: "1003", NumCSQ: 1, CSQIDs: []uint32{3}},
+	}
+	if !reflect.DeepEqual(m.Records, want) {
+		t.Errorf("Records =\n%+v\nwant\n%+v", m.Records, want)
+	}
+}
+
+func TestConfigDeviceEventDecodeSparseRecords(t *testing.T) {
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteString(protocol.TagAgentInstrument, "4001")
+	fw.WriteUint32(protocol.TagServiceID, 6000)
+	fw.WriteString(protocol.TagAgentInstrument, "4002")
+	fw.WriteUint16(protocol.TagDeviceType, 2)
+	fw.WriteUint32(protocol.TagSkillGroupID, 5011)
+
+	var m ConfigDeviceEvent
+	if err := m.Decode(configEventBody(2, fw)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DeviceConfigRecord{
+		{DeviceID: "4001", ServiceID: 6000},
+		{DeviceID: "4002", DeviceType: 2, SkillGroupID: 5011},
+	}
+	if !reflect.DeepEqual(m.Records, want) {
+		t.Errorf("Records =\n%+v\nwant\n%+v", m.Records, want)
+	}
+}
+
+func TestConfigCSQEventDecodeSparseRecords(t *testing.T) {
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteUint32(protocol.TagCSQID, 1)
+	fw.WriteUint32(protocol.TagServiceNumber, 60)
+	fw.WriteUint32(protocol.TagCSQID, 2)
+	fw.WriteUint32(protocol.TagSkillGroupID, 5011)
+	fw.WriteUint32(protocol.TagSkillGroupNumber, 11)
+
+	var m ConfigCSQEvent
+	if err := m.Decode(configEventBody(2, fw)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []CSQConfigRecord{
+		{CSQID: 1, ServiceNumber: 60},
+		{CSQID: 2, SkillGroupID: 5011, SkillGroupNumber: 11},
+	}
+	if !reflect.DeepEqual(m.Records, want) {
+		t.Errorf("Records =\n%+v\nwant\n%+v", m.Records, want)
+	}
+}
+
+func TestConfigApplicationEventDecodeSparseRecords(t *testing.T) {
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteUint32(protocol.TagApplicationID, 1)
+	fw.WriteUint16(protocol.TagApplicationEnabled, 1)
+	fw.WriteUint32(protocol.TagApplicationID, 2)
+	fw.WriteString(protocol.TagApplicationName, "Survey")
+
+	var m ConfigApplicationEvent
+	if err := m.Decode(configEventBody(2, fw)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ApplicationConfigRecord{
+		{ApplicationID: 1, Enabled: true},
+		{ApplicationID: 2, ApplicationName: "Survey"},
+	}
+	if !reflect.DeepEqual(m.Records, want) {
+		t.Errorf("Records =\n%+v\nwant\n%+v", m.Records, want)
+	}
+}
@@ -0,0 +1,192 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// Line represents one line appearance on a device in QUERY_DEVICE_INFO_CONF.
+type Line struct {
+	LineHandle uint16 // Tag 70
+	LineType   uint16 // Tag 71
+}
+
+// QueryDeviceInfoReq is sent to retrieve the capabilities of a device.
+// Protocol Version 24 - QUERY_DEVICE_INFO_REQ (MessageType = 78)
+type QueryDeviceInfoReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+	Reserved     uint16 // Reserved (USHORT)
+
+	// Floating fields
+	AgentInstrument string // Tag 5 (max 64 bytes)
+}
+
+func (m *QueryDeviceInfoReq) Type() uint32 {
+	return protocol.MsgTypeQueryDeviceInfoReq
+}
+
+func (m *QueryDeviceInfoReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryDeviceInfoReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// QueryDeviceInfoConf returns the peripheral type, capabilities and lines of a device.
+// Protocol Version 24 - QUERY_DEVICE_INFO_CONF (MessageType = 79)
+type QueryDeviceInfoConf struct {
+	// Fixed Part
+	InvokeID                uint32 // Matches QueryDeviceInfoReq InvokeID (UINT)
+	PeripheralType          uint16 // Peripheral type (USHORT)
+	TypeOfDevice            uint16 // Type of device (USHORT)
+	ClassOfDevice           uint16 // Class of device (USHORT)
+	NumLines                uint16 // Number of lines (USHORT)
+	Reserved                uint16 // Reserved (USHORT)
+	MaxActiveCalls          uint16 // Maximum active calls (USHORT)
+	MaxHeldCalls            uint16 // Maximum held calls (USHORT)
+	MaxDevicesInConference  uint16 // Maximum devices in a conference (USHORT)
+	MakeCallSetup           uint32 // Make call setup mask (UINT)
+	TransferConferenceSetup uint32 // Transfer/conference setup mask (UINT)
+	CallEventsProvided      uint32 // Call events provided mask (UINT)
+	CallControlSupported    uint32 // Call control supported mask (UINT)
+	OtherFeaturesSupported  uint32 // Other features supported mask (UINT)
+
+	// Lines contains repeating line info (up to NumLines)
+	Lines []Line
+}
+
+func (m *QueryDeviceInfoConf) Type() uint32 {
+	return protocol.MsgTypeQueryDeviceInfoConf
+}
+
+func (m *QueryDeviceInfoConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(m.PeripheralType)
+	w.WriteUint16(m.TypeOfDevice)
+	w.WriteUint16(m.ClassOfDevice)
+	w.WriteUint16(uint16(len(m.Lines)))
+	w.WriteUint16(m.Reserved)
+	w.WriteUint16(m.MaxActiveCalls)
+	w.WriteUint16(m.MaxHeldCalls)
+	w.WriteUint16(m.MaxDevicesInConference)
+	w.WriteUint32(m.MakeCallSetup)
+	w.WriteUint32(m.TransferConferenceSetup)
+	w.WriteUint32(m.CallEventsProvided)
+	w.WriteUint32(m.CallControlSupported)
+	w.WriteUint32(m.OtherFeaturesSupported)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Write Line repeated fields
+	fw := protocol.NewFloatingFieldWriter()
+	for _, line := range m.Lines {
+		fw.WriteUint16(protocol.TagLineHandle, line.LineHandle)
+		fw.WriteUint16(protocol.TagLineType, line.LineType)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryDeviceInfoConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralType = r.ReadUint16()
+	m.TypeOfDevice = r.ReadUint16()
+	m.ClassOfDevice = r.ReadUint16()
+	m.NumLines = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+	m.MaxActiveCalls = r.ReadUint16()
+	m.MaxHeldCalls = r.ReadUint16()
+	m.MaxDevicesInConference = r.ReadUint16()
+	m.MakeCallSetup = r.ReadUint32()
+	m.TransferConferenceSetup = r.ReadUint32()
+	m.CallEventsProvided = r.ReadUint32()
+	m.CallControlSupported = r.ReadUint32()
+	m.OtherFeaturesSupported = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+
+		// Build Lines list from parallel arrays
+		handles := ff.GetAllUint16(protocol.TagLineHandle)
+		types := ff.GetAllUint16(protocol.TagLineType)
+
+		m.Lines = make([]Line, len(handles))
+		for i := range handles {
+			m.Lines[i].LineHandle = handles[i]
+			if i < len(types) {
+				m.Lines[i].LineType = types[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *QueryDeviceInfoConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// SupportsCallControl reports whether every bit of mask is set in CallControlSupported.
+func (m *QueryDeviceInfoConf) SupportsCallControl(mask uint32) bool {
+	return m.CallControlSupported&mask == mask
+}
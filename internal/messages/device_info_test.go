@@ -0,0 +1,31 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"testing"
+)
+
+func TestDeviceInfoRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&QueryDeviceInfoReq{InvokeID: 14, PeripheralID: 5000, AgentInstrument: "4001"},
+		&QueryDeviceInfoConf{
+			InvokeID:                14,
+			PeripheralType:          1,
+			TypeOfDevice:            2,
+			ClassOfDevice:           3,
+			NumLines:                2,
+			MaxActiveCalls:          1,
+			MaxHeldCalls:            1,
+			MaxDevicesInConference:  6,
+			MakeCallSetup:           0x1,
+			TransferConferenceSetup: 0x2,
+			CallEventsProvided:      0x3,
+			CallControlSupported:    0x4,
+			OtherFeaturesSupported:  0x5,
+			Lines: []Line{
+				{LineHandle: 0, LineType: 1},
+				{LineHandle: 1, LineType: 2},
+			},
+		},
+	})
+}
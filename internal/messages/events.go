@@ -4,6 +4,13 @@ import (
 	"ctiservice/internal/protocol"
 )
 
+// Confirmation is implemented by server responses that echo the InvokeID of
+// the request they answer, allowing the client to correlate them.
+type Confirmation interface {
+	protocol.Message
+	GetInvokeID() uint32
+}
+
 // FailureConf is sent when a request fails.
 // Protocol Version 24 - FAILURE_CONF (MessageType = 1)
 type FailureConf struct {
@@ -29,6 +36,47 @@ func (m *FailureConf) Decode(data []byte) error {
 	return r.Error()
 }
 
+func (m *FailureConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// ControlFailureConf is sent when a call control request fails at the peripheral.
+// Protocol Version 24 - CONTROL_FAILURE_CONF (MessageType = 35)
+type ControlFailureConf struct {
+	InvokeID            uint32 // Matches the request's InvokeID (UINT)
+	FailureCode         uint16 // Failure code (USHORT)
+	PeripheralErrorCode uint32 // Error code from the peripheral, meaning depends on the switch (UINT)
+}
+
+func (m *ControlFailureConf) Type() uint32 {
+	return protocol.MsgTypeControlFailureConf
+}
+
+func (m *ControlFailureConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(m.FailureCode)
+	w.WriteUint32(m.PeripheralErrorCode)
+	return w.Bytes(), w.Error()
+}
+
+func (m *ControlFailureConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.FailureCode = r.ReadUint16()
+	m.PeripheralErrorCode = r.ReadUint32()
+	return r.Error()
+}
+
+func (m *ControlFailureConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// FailureName returns a human-readable name for the failure code.
+func (m *ControlFailureConf) FailureName() string {
+	return protocol.ControlFailureName(m.FailureCode)
+}
+
 // FailureEvent is an unsolicited error notification.
 // Protocol Version 24 - FAILURE_EVENT (MessageType = 2)
 type FailureEvent struct {
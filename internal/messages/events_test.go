@@ -0,0 +1,12 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"testing"
+)
+
+func TestControlFailureConfRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&ControlFailureConf{InvokeID: 2, FailureCode: 3, PeripheralErrorCode: 1234},
+	})
+}
@@ -154,6 +154,10 @@ type AgentPreCallEvent struct {
 	RouterCallKeyDay    uint32 // Tag 72
 	RouterCallKeyCallID uint32 // Tag 73
 	RouterCallKeySeqNum uint32 // Tag 214
+
+	// ECC variables
+	NamedVariables map[string]string   // Tag 82
+	NamedArrays    map[string][]string // Tag 83
 }
 
 func (m *AgentPreCallEvent) Type() uint32 {
@@ -173,8 +177,8 @@ func (m *AgentPreCallEvent) Encode() ([]byte, error) {
 	w.WriteUint32(m.SkillGroupID)
 	w.WriteUint16(m.SkillGroupPriority)
 	w.WriteUint16(m.NumCTIClients)
-	w.WriteUint16(m.NumNamedVariables)
-	w.WriteUint16(m.NumNamedArrays)
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
 	w.WriteUint16(m.CallType)
 
 	if err := w.Error(); err != nil {
@@ -236,6 +240,8 @@ func (m *AgentPreCallEvent) Encode() ([]byte, error) {
 	if m.PreCallInvokeID != 0 {
 		fw.WriteUint32(protocol.TagPreCallInvokeID, m.PreCallInvokeID)
 	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
 
 	fixed := w.Bytes()
 	floating := fw.Bytes()
@@ -288,6 +294,8 @@ func (m *AgentPreCallEvent) Decode(data []byte) error {
 		m.CallVariable8 = ff.GetString(protocol.TagCallVariable8)
 		m.CallVariable9 = ff.GetString(protocol.TagCallVariable9)
 		m.CallVariable10 = ff.GetString(protocol.TagCallVariable10)
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
 		m.CallTypeID = ff.GetUint32(protocol.TagCallTypeID)
 		m.PreCallInvokeID = ff.GetUint32(protocol.TagPreCallInvokeID)
 		m.RouterCallKeyDay = ff.GetUint32(protocol.TagRouterCallKeyDay)
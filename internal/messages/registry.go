@@ -52,6 +52,8 @@ func (r *Registry) Create(msgType uint32) protocol.Message {
 		return &FailureConf{}
 	case protocol.MsgTypeFailureEvent:
 		return &FailureEvent{}
+	case protocol.MsgTypeControlFailureConf:
+		return &ControlFailureConf{}
 
 	// System events
 	case protocol.MsgTypeSystemEvent:
@@ -84,6 +86,8 @@ func (r *Registry) Create(msgType uint32) protocol.Message {
 		return &CallConferencedEvent{}
 	case protocol.MsgTypeCallTransferredEvent:
 		return &CallTransferredEvent{}
+	case protocol.MsgTypeCallDivertedEvent:
+		return &CallDivertedEvent{}
 	case protocol.MsgTypeCallQueuedEvent:
 		return &CallQueuedEvent{}
 	case protocol.MsgTypeCallDequeuedEvent:
@@ -94,6 +98,14 @@ func (r *Registry) Create(msgType uint32) protocol.Message {
 	// Agent events
 	case protocol.MsgTypeAgentStateEvent:
 		return &AgentStateEvent{}
+	case protocol.MsgTypeSetAgentStateReq:
+		return &SetAgentStateReq{}
+	case protocol.MsgTypeSetAgentStateConf:
+		return &SetAgentStateConf{}
+	case protocol.MsgTypeQueryAgentStateReq:
+		return &QueryAgentStateReq{}
+	case protocol.MsgTypeQueryAgentStateConf:
+		return &QueryAgentStateConf{}
 	case protocol.MsgTypeAgentPreCallEvent:
 		return &AgentPreCallEvent{}
 	case protocol.MsgTypeAgentPreCallAbortEvent:
@@ -120,10 +132,84 @@ func (r *Registry) Create(msgType uint32) protocol.Message {
 		return &RetrieveCallReq{}
 	case protocol.MsgTypeRetrieveCallConf:
 		return &RetrieveCallConf{}
+	case protocol.MsgTypeMakeCallReq:
+		return &MakeCallReq{}
+	case protocol.MsgTypeMakeCallConf:
+		return &MakeCallConf{}
+	case protocol.MsgTypeAnswerCallReq:
+		return &AnswerCallReq{}
+	case protocol.MsgTypeAnswerCallConf:
+		return &AnswerCallConf{}
+	case protocol.MsgTypeClearCallReq:
+		return &ClearCallReq{}
+	case protocol.MsgTypeClearCallConf:
+		return &ClearCallConf{}
+	case protocol.MsgTypeClearConnectionReq:
+		return &ClearConnectionReq{}
+	case protocol.MsgTypeClearConnectionConf:
+		return &ClearConnectionConf{}
+	case protocol.MsgTypeAlternateCallReq:
+		return &AlternateCallReq{}
+	case protocol.MsgTypeAlternateCallConf:
+		return &AlternateCallConf{}
+	case protocol.MsgTypeReconnectCallReq:
+		return &ReconnectCallReq{}
+	case protocol.MsgTypeReconnectCallConf:
+		return &ReconnectCallConf{}
+	case protocol.MsgTypeSetCallDataReq:
+		return &SetCallDataReq{}
+	case protocol.MsgTypeSetCallDataConf:
+		return &SetCallDataConf{}
+	case protocol.MsgTypeSendDTMFSignalReq:
+		return &SendDTMFSignalReq{}
+	case protocol.MsgTypeSendDTMFSignalConf:
+		return &SendDTMFSignalConf{}
+	case protocol.MsgTypeBadCallReq:
+		return &BadCallReq{}
+	case protocol.MsgTypeBadCallConf:
+		return &BadCallConf{}
+	case protocol.MsgTypeQueryDeviceInfoReq:
+		return &QueryDeviceInfoReq{}
+	case protocol.MsgTypeQueryDeviceInfoConf:
+		return &QueryDeviceInfoConf{}
+	case protocol.MsgTypeSnapshotCallReq:
+		return &SnapshotCallReq{}
+	case protocol.MsgTypeSnapshotCallConf:
+		return &SnapshotCallConf{}
+	case protocol.MsgTypeSnapshotDeviceReq:
+		return &SnapshotDeviceReq{}
+	case protocol.MsgTypeSnapshotDeviceConf:
+		return &SnapshotDeviceConf{}
 
 	// Supervisor events
 	case protocol.MsgTypeSupervisorAssistEvent:
 		return &SupervisorAssistEvent{}
+	case protocol.MsgTypeQueryQueueStatisticsReq:
+		return &QueryQueueStatisticsReq{}
+	case protocol.MsgTypeQueryQueueStatisticsConf:
+		return &QueryQueueStatisticsConf{}
+	case protocol.MsgTypeQuerySummaryStatisticsReq:
+		return &QuerySummaryStatisticsReq{}
+	case protocol.MsgTypeQuerySummaryStatisticsConf:
+		return &QuerySummaryStatisticsConf{}
+	case protocol.MsgTypeQueryAgentQueueStatisticsReq:
+		return &QueryAgentQueueStatisticsReq{}
+	case protocol.MsgTypeQueryAgentQueueStatisticsConf:
+		return &QueryAgentQueueStatisticsConf{}
+	case protocol.MsgTypeTeamConfigReq:
+		return &TeamConfigReq{}
+	case protocol.MsgTypeTeamConfigEvent:
+		return &TeamConfigEvent{}
+	case protocol.MsgTypeTeamConfigConf:
+		return &TeamConfigConf{}
+	case protocol.MsgTypeSupervisorAssistReq:
+		return &SupervisorAssistReq{}
+	case protocol.MsgTypeSupervisorAssistConf:
+		return &SupervisorAssistConf{}
+	case protocol.MsgTypeSuperviseCallReq:
+		return &SuperviseCallReq{}
+	case protocol.MsgTypeSuperviseCallConf:
+		return &SuperviseCallConf{}
 
 	// Config events
 	case protocol.MsgTypeConfigAgentEvent:
@@ -138,6 +224,18 @@ func (r *Registry) Create(msgType uint32) protocol.Message {
 		return &ConfigEndEvent{}
 	case protocol.MsgTypeConfigRequestEvent:
 		return &ConfigRequestEvent{}
+	case protocol.MsgTypeConfigRequestKeyEvent:
+		return &ConfigRequestKeyEvent{}
+	case protocol.MsgTypeConfigKeyEvent:
+		return &ConfigKeyEvent{}
+	case protocol.MsgTypeConfigApplicationEvent:
+		return &ConfigApplicationEvent{}
+
+	// RTP events
+	case protocol.MsgTypeRTPStartedEvent:
+		return &RTPStartedEvent{}
+	case protocol.MsgTypeRTPStoppedEvent:
+		return &RTPStoppedEvent{}
 
 	default:
 		// Return a generic message for unknown types
@@ -0,0 +1,64 @@
+package messages
+
+import (
+	"bytes"
+	"ctiservice/internal/protocol"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// roundTrip encodes msg and parses the body back through the registry.
+func roundTrip(t *testing.T, msg protocol.Message) protocol.Message {
+	t.Helper()
+
+	data, err := protocol.EncodeMessage(msg)
+	if err != nil {
+		t.Fatalf("encode %T: %v", msg, err)
+	}
+	header, err := protocol.ReadHeader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("header of %T: %v", msg, err)
+	}
+	if header.MessageType != msg.Type() {
+		t.Fatalf("%T encoded as type %d, want %d", msg, header.MessageType, msg.Type())
+	}
+	if int(header.MessageLength) != len(data)-protocol.HeaderSize {
+		t.Fatalf("%T header length %d, body is %d bytes", msg, header.MessageLength, len(data)-protocol.HeaderSize)
+	}
+
+	got, err := NewRegistry().Parse(header.MessageType, data[protocol.HeaderSize:])
+	if err != nil {
+		t.Fatalf("parse %T: %v", msg, err)
+	}
+	return got
+}
+
+// testRoundTrips checks that each message parses back to itself.
+func testRoundTrips(t *testing.T, tests []protocol.Message) {
+	t.Helper()
+	for _, want := range tests {
+		got := roundTrip(t, want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%T round trip:\ngot  %+v\nwant %+v", want, got, want)
+		}
+	}
+}
+
+// Every confirmation must carry its InvokeID to the request waiting for it.
+func TestConfirmationsHaveInvokeID(t *testing.T) {
+	r := NewRegistry()
+	for msgType := uint32(0); msgType < 1024; msgType++ {
+		msg := r.Create(msgType)
+		if msg == nil {
+			continue
+		}
+		name := reflect.TypeOf(msg).Elem().Name()
+		if !strings.HasSuffix(name, "Conf") {
+			continue
+		}
+		if _, ok := msg.(Confirmation); !ok {
+			t.Errorf("%s does not implement Confirmation", name)
+		}
+	}
+}
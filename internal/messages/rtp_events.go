@@ -0,0 +1,362 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// RTPDirection identifies which way an RTP stream flows relative to the agent's phone.
+type RTPDirection uint32
+
+const (
+	RTPDirectionInput         RTPDirection = 0 // Stream received by the phone
+	RTPDirectionOutput        RTPDirection = 1 // Stream sent by the phone
+	RTPDirectionBidirectional RTPDirection = 2
+)
+
+// RTPDirectionName returns a human-readable name for an RTP direction.
+func RTPDirectionName(d RTPDirection) string {
+	switch d {
+	case RTPDirectionInput:
+		return "Input"
+	case RTPDirectionOutput:
+		return "Output"
+	case RTPDirectionBidirectional:
+		return "Bidirectional"
+	default:
+		return "Unknown"
+	}
+}
+
+// RTPType identifies the kind of media carried by an RTP stream.
+type RTPType uint32
+
+const (
+	RTPTypeAudio RTPType = 0
+	RTPTypeVideo RTPType = 1
+	RTPTypeData  RTPType = 2
+)
+
+// RTPTypeName returns a human-readable name for an RTP media type.
+func RTPTypeName(t RTPType) string {
+	switch t {
+	case RTPTypeAudio:
+		return "Audio"
+	case RTPTypeVideo:
+		return "Video"
+	case RTPTypeData:
+		return "Data"
+	default:
+		return "Unknown"
+	}
+}
+
+// RTPPayloadType identifies the codec of an RTP stream.
+type RTPPayloadType uint32
+
+const (
+	RTPPayloadNonStandard         RTPPayloadType = 0
+	RTPPayloadG711ALaw64k         RTPPayloadType = 1
+	RTPPayloadG711ALaw56k         RTPPayloadType = 2
+	RTPPayloadG711ULaw64k         RTPPayloadType = 3
+	RTPPayloadG711ULaw56k         RTPPayloadType = 4
+	RTPPayloadG722_64k            RTPPayloadType = 5
+	RTPPayloadG722_56k            RTPPayloadType = 6
+	RTPPayloadG722_48k            RTPPayloadType = 7
+	RTPPayloadG7231               RTPPayloadType = 8
+	RTPPayloadG728                RTPPayloadType = 9
+	RTPPayloadG729                RTPPayloadType = 10
+	RTPPayloadG729AnnexA          RTPPayloadType = 11
+	RTPPayloadG729AnnexB          RTPPayloadType = 14
+	RTPPayloadG729AnnexAwAnnexB   RTPPayloadType = 15
+	RTPPayloadGSMFullRate         RTPPayloadType = 16
+	RTPPayloadGSMHalfRate         RTPPayloadType = 17
+	RTPPayloadGSMEnhancedFullRate RTPPayloadType = 18
+)
+
+// RTPPayloadTypeName returns a human-readable codec name for an RTP payload type.
+func RTPPayloadTypeName(p RTPPayloadType) string {
+	switch p {
+	case RTPPayloadNonStandard:
+		return "NonStandard"
+	case RTPPayloadG711ALaw64k:
+		return "G.711 A-law 64k"
+	case RTPPayloadG711ALaw56k:
+		return "G.711 A-law 56k"
+	case RTPPayloadG711ULaw64k:
+		return "G.711 u-law 64k"
+	case RTPPayloadG711ULaw56k:
+		return "G.711 u-law 56k"
+	case RTPPayloadG722_64k:
+		return "G.722 64k"
+	case RTPPayloadG722_56k:
+		return "G.722 56k"
+	case RTPPayloadG722_48k:
+		return "G.722 48k"
+	case RTPPayloadG7231:
+		return "G.723.1"
+	case RTPPayloadG728:
+		return "G.728"
+	case RTPPayloadG729:
+		return "G.729"
+	case RTPPayloadG729AnnexA:
+		return "G.729 Annex A"
+	case RTPPayloadG729AnnexB:
+		return "G.729 Annex B"
+	case RTPPayloadG729AnnexAwAnnexB:
+		return "G.729 Annex A with Annex B"
+	case RTPPayloadGSMFullRate:
+		return "GSM Full Rate"
+	case RTPPayloadGSMHalfRate:
+		return "GSM Half Rate"
+	case RTPPayloadGSMEnhancedFullRate:
+		return "GSM Enhanced Full Rate"
+	default:
+		return "Unknown"
+	}
+}
+
+// RTPStartedEvent is sent when an RTP media stream starts on an agent's phone.
+// Used by call recorders to associate media streams with calls.
+// Protocol Version 24 - RTP_STARTED_EVENT (MessageType = 116)
+type RTPStartedEvent struct {
+	// Fixed Part
+	MonitorID              uint32 // Monitor ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ClientPort             uint32 // Client port (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	Direction              uint32 // RTPDirection (UINT)
+	RTPType                uint32 // RTPType (UINT)
+	BitRate                uint32 // Media bit rate (UINT)
+	EchoCancellation       bool   // Echo cancellation enabled (BOOL)
+	PacketSize             uint32 // Packet size in milliseconds (UINT)
+	PayloadType            uint32 // RTPPayloadType (UINT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31 - Connection device ID
+	SendingAddress     string // Tag 215 - IP address the stream is sent from
+	SendingPort        uint32 // Tag 216 - UDP port the stream is sent from
+	ReceivingAddress   string // Tag 217 - IP address the stream is sent to
+	ReceivingPort      uint32 // Tag 218 - UDP port the stream is sent to
+	AgentExtension     string // Tag 3 - Agent extension (optional)
+	AgentID            string // Tag 4 - Agent ID (optional)
+	AgentInstrument    string // Tag 5 - Agent instrument (optional)
+}
+
+func (m *RTPStartedEvent) Type() uint32 {
+	return protocol.MsgTypeRTPStartedEvent
+}
+
+func (m *RTPStartedEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.MonitorID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ClientPort)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint32(m.Direction)
+	w.WriteUint32(m.RTPType)
+	w.WriteUint32(m.BitRate)
+	w.WriteBool(m.EchoCancellation)
+	w.WriteUint32(m.PacketSize)
+	w.WriteUint32(m.PayloadType)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.SendingAddress != "" {
+		fw.WriteString(protocol.TagSendingAddress, m.SendingAddress)
+	}
+	if m.SendingPort != 0 {
+		fw.WriteUint32(protocol.TagSendingPort, m.SendingPort)
+	}
+	if m.ReceivingAddress != "" {
+		fw.WriteString(protocol.TagReceivingAddress, m.ReceivingAddress)
+	}
+	if m.ReceivingPort != 0 {
+		fw.WriteUint32(protocol.TagReceivingPort, m.ReceivingPort)
+	}
+	if m.AgentExtension != "" {
+		fw.WriteString(protocol.TagAgentExtension, m.AgentExtension)
+	}
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *RTPStartedEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.MonitorID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ClientPort = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.ConnectionCallID = r.ReadUint32()
+	m.Direction = r.ReadUint32()
+	m.RTPType = r.ReadUint32()
+	m.BitRate = r.ReadUint32()
+	m.EchoCancellation = r.ReadBool()
+	m.PacketSize = r.ReadUint32()
+	m.PayloadType = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.SendingAddress = ff.GetString(protocol.TagSendingAddress)
+		m.SendingPort = ff.GetUint32(protocol.TagSendingPort)
+		m.ReceivingAddress = ff.GetString(protocol.TagReceivingAddress)
+		m.ReceivingPort = ff.GetUint32(protocol.TagReceivingPort)
+		m.AgentExtension = ff.GetString(protocol.TagAgentExtension)
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// DirectionName returns a human-readable name for the stream direction.
+func (m *RTPStartedEvent) DirectionName() string {
+	return RTPDirectionName(RTPDirection(m.Direction))
+}
+
+// RTPTypeName returns a human-readable name for the stream media type.
+func (m *RTPStartedEvent) RTPTypeName() string {
+	return RTPTypeName(RTPType(m.RTPType))
+}
+
+// CodecName returns a human-readable name for the stream codec.
+func (m *RTPStartedEvent) CodecName() string {
+	return RTPPayloadTypeName(RTPPayloadType(m.PayloadType))
+}
+
+// RTPStoppedEvent is sent when an RTP media stream stops on an agent's phone.
+// Protocol Version 24 - RTP_STOPPED_EVENT (MessageType = 117)
+type RTPStoppedEvent struct {
+	// Fixed Part
+	MonitorID              uint32 // Monitor ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ClientPort             uint32 // Client port (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	Direction              uint32 // RTPDirection (UINT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31 - Connection device ID
+	SendingAddress     string // Tag 215 - IP address the stream was sent from
+	SendingPort        uint32 // Tag 216 - UDP port the stream was sent from
+	ReceivingAddress   string // Tag 217 - IP address the stream was sent to
+	ReceivingPort      uint32 // Tag 218 - UDP port the stream was sent to
+	AgentExtension     string // Tag 3 - Agent extension (optional)
+	AgentID            string // Tag 4 - Agent ID (optional)
+	AgentInstrument    string // Tag 5 - Agent instrument (optional)
+}
+
+func (m *RTPStoppedEvent) Type() uint32 {
+	return protocol.MsgTypeRTPStoppedEvent
+}
+
+func (m *RTPStoppedEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.MonitorID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ClientPort)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint32(m.Direction)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.SendingAddress != "" {
+		fw.WriteString(protocol.TagSendingAddress, m.SendingAddress)
+	}
+	if m.SendingPort != 0 {
+		fw.WriteUint32(protocol.TagSendingPort, m.SendingPort)
+	}
+	if m.ReceivingAddress != "" {
+		fw.WriteString(protocol.TagReceivingAddress, m.ReceivingAddress)
+	}
+	if m.ReceivingPort != 0 {
+		fw.WriteUint32(protocol.TagReceivingPort, m.ReceivingPort)
+	}
+	if m.AgentExtension != "" {
+		fw.WriteString(protocol.TagAgentExtension, m.AgentExtension)
+	}
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *RTPStoppedEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.MonitorID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ClientPort = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.ConnectionCallID = r.ReadUint32()
+	m.Direction = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.SendingAddress = ff.GetString(protocol.TagSendingAddress)
+		m.SendingPort = ff.GetUint32(protocol.TagSendingPort)
+		m.ReceivingAddress = ff.GetString(protocol.TagReceivingAddress)
+		m.ReceivingPort = ff.GetUint32(protocol.TagReceivingPort)
+		m.AgentExtension = ff.GetString(protocol.TagAgentExtension)
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// DirectionName returns a human-readable name for the stream direction.
+func (m *RTPStoppedEvent) DirectionName() string {
+	return RTPDirectionName(RTPDirection(m.Direction))
+}
@@ -0,0 +1,48 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"testing"
+)
+
+func TestRTPEventsRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&RTPStartedEvent{
+			MonitorID:              1,
+			PeripheralID:           5000,
+			ClientPort:             2,
+			ConnectionDeviceIDType: 1,
+			ConnectionCallID:       100,
+			Direction:              1,
+			RTPType:                1,
+			BitRate:                64000,
+			EchoCancellation:       true,
+			PacketSize:             20,
+			PayloadType:            4,
+			ConnectionDeviceID:     "4001",
+			SendingAddress:         "10.0.0.1",
+			SendingPort:            16384,
+			ReceivingAddress:       "10.0.0.2",
+			ReceivingPort:          16386,
+			AgentExtension:         "4001",
+			AgentID:                "1001",
+			AgentInstrument:        "4001",
+		},
+		&RTPStoppedEvent{
+			MonitorID:              1,
+			PeripheralID:           5000,
+			ClientPort:             2,
+			ConnectionDeviceIDType: 1,
+			ConnectionCallID:       100,
+			Direction:              1,
+			ConnectionDeviceID:     "4001",
+			SendingAddress:         "10.0.0.1",
+			SendingPort:            16384,
+			ReceivingAddress:       "10.0.0.2",
+			ReceivingPort:          16386,
+			AgentExtension:         "4001",
+			AgentID:                "1001",
+			AgentInstrument:        "4001",
+		},
+	})
+}
@@ -75,6 +75,9 @@ func (m *OpenReq) Encode() ([]byte, error) {
 	if m.AgentInstrument != "" {
 		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
 	}
+	if m.ApplicationPathID != 0 {
+		fw.WriteUint32(protocol.TagApplicationPathID, uint32(m.ApplicationPathID))
+	}
 
 	// Combine fixed and floating parts
 	fixed := w.Bytes()
@@ -117,6 +120,7 @@ func (m *OpenReq) Decode(data []byte) error {
 		m.AgentExtension = ff.GetString(protocol.TagAgentExtension)
 		m.AgentID = ff.GetString(protocol.TagAgentID)
 		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+		m.ApplicationPathID = ff.GetInt32(protocol.TagApplicationPathID)
 	}
 
 	return nil
@@ -202,6 +206,11 @@ func (m *OpenConf) Decode(data []byte) error {
 	return nil
 }
 
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *OpenConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
 // HeartbeatReq is sent by the client to maintain the connection.
 // Protocol Version 24 - HEARTBEAT_REQ (MessageType = 5)
 type HeartbeatReq struct {
@@ -246,6 +255,11 @@ func (m *HeartbeatConf) Decode(data []byte) error {
 	return r.Error()
 }
 
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *HeartbeatConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
 // CloseReq is sent to gracefully close a session.
 // Protocol Version 24 - CLOSE_REQ (MessageType = 7)
 type CloseReq struct {
@@ -292,3 +306,8 @@ func (m *CloseConf) Decode(data []byte) error {
 	m.InvokeID = r.ReadUint32()
 	return r.Error()
 }
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *CloseConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
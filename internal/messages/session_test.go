@@ -0,0 +1,28 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"testing"
+)
+
+func TestOpenReqRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&OpenReq{
+			InvokeID:          1,
+			VersionNumber:     24,
+			IdleTimeout:       120,
+			PeripheralID:      5000,
+			ServicesRequested: protocol.ServiceClientEvents,
+			CallMsgMask:       0xFFFF,
+			AgentStateMask:    0x3FFF,
+			ConfigMsgMask:     0x7,
+			ClientID:          "client",
+			ClientPassword:    "secret",
+			ClientSignature:   "sig",
+			AgentExtension:    "4001",
+			AgentID:           "1001",
+			AgentInstrument:   "4001",
+			ApplicationPathID: 42,
+		},
+	})
+}
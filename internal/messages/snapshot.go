@@ -0,0 +1,472 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// CallDevice represents one device (party) on a call in SNAPSHOT_CALL_CONF.
+type CallDevice struct {
+	ConnectionCallID       uint32 // Tag 56
+	ConnectionDeviceIDType uint16 // Tag 57
+	ConnectionDeviceID     string // Tag 58
+	DeviceIDType           uint16 // Tag 59
+	DeviceID               string // Tag 60
+	ConnectionState        uint16 // Tag 61
+}
+
+// DeviceCall represents one call present at a device in SNAPSHOT_DEVICE_CONF.
+type DeviceCall struct {
+	ConnectionCallID       uint32 // Tag 56
+	ConnectionDeviceIDType uint16 // Tag 57
+	ConnectionDeviceID     string // Tag 58
+	CallState              uint16 // Tag 75 - local connection state of the device
+}
+
+// SnapshotCallReq is sent to retrieve the current state of a call.
+// Protocol Version 24 - SNAPSHOT_CALL_REQ (MessageType = 82)
+type SnapshotCallReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Call ID (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	Reserved               uint16 // Reserved (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+}
+
+func (m *SnapshotCallReq) Type() uint32 {
+	return protocol.MsgTypeSnapshotCallReq
+}
+
+func (m *SnapshotCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SnapshotCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+	}
+
+	return nil
+}
+
+// SnapshotCallConf returns the current state of a call and its devices.
+// Protocol Version 24 - SNAPSHOT_CALL_CONF (MessageType = 83)
+type SnapshotCallConf struct {
+	// Fixed Part
+	InvokeID               uint32 // Matches SnapshotCallReq InvokeID (UINT)
+	CallType               uint16 // Type of call (USHORT)
+	NumCTIClients          uint16 // Number of CTI clients (USHORT)
+	NumCallDevices         uint16 // Number of devices on the call (USHORT)
+	NumNamedVariables      uint16 // Number of named variables (USHORT)
+	NumNamedArrays         uint16 // Number of named arrays (USHORT)
+	CalledPartyDisposition uint16 // Called party disposition (USHORT)
+	CampaignID             uint32 // Campaign ID (UINT)
+	QueryRuleID            uint32 // Query rule ID (UINT)
+
+	// Floating fields
+	ANI                 string // Tag 15
+	UserToUserInfo      string // Tag 17
+	DNIS                string // Tag 16
+	DialedNumber        string // Tag 40
+	CallerEnteredDigits string // Tag 41
+	RouterCallKeyDay    uint32 // Tag 72
+	RouterCallKeyCallID uint32 // Tag 73
+	RouterCallKeySeqNum uint32 // Tag 214
+	CallVariable1       string // Tag 18
+	CallVariable2       string // Tag 19
+	CallVariable3       string // Tag 20
+	CallVariable4       string // Tag 21
+	CallVariable5       string // Tag 22
+	CallVariable6       string // Tag 23
+	CallVariable7       string // Tag 24
+	CallVariable8       string // Tag 25
+	CallVariable9       string // Tag 26
+	CallVariable10      string // Tag 27
+	CallWrapupData      string // Tag 30
+
+	// ECC variables
+	NamedVariables map[string]string   // Tag 82
+	NamedArrays    map[string][]string // Tag 83
+
+	// Devices contains repeating device info (up to NumCallDevices)
+	Devices []CallDevice
+}
+
+func (m *SnapshotCallConf) Type() uint32 {
+	return protocol.MsgTypeSnapshotCallConf
+}
+
+func (m *SnapshotCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(m.CallType)
+	w.WriteUint16(m.NumCTIClients)
+	w.WriteUint16(uint16(len(m.Devices)))
+	w.WriteUint16(uint16(len(m.NamedVariables)))
+	w.WriteUint16(uint16(protocol.NamedArrayLen(m.NamedArrays)))
+	w.WriteUint16(m.CalledPartyDisposition)
+	w.WriteUint32(m.CampaignID)
+	w.WriteUint32(m.QueryRuleID)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ANI != "" {
+		fw.WriteString(protocol.TagANI, m.ANI)
+	}
+	if m.UserToUserInfo != "" {
+		fw.WriteString(protocol.TagUserToUserInfo, m.UserToUserInfo)
+	}
+	if m.DNIS != "" {
+		fw.WriteString(protocol.TagDNIS, m.DNIS)
+	}
+	if m.DialedNumber != "" {
+		fw.WriteString(protocol.TagDialedNumber, m.DialedNumber)
+	}
+	if m.CallerEnteredDigits != "" {
+		fw.WriteString(protocol.TagCallerEnteredDigits, m.CallerEnteredDigits)
+	}
+	if m.RouterCallKeyDay != 0 {
+		fw.WriteUint32(protocol.TagRouterCallKeyDay, m.RouterCallKeyDay)
+	}
+	if m.RouterCallKeyCallID != 0 {
+		fw.WriteUint32(protocol.TagRouterCallKeyCallID, m.RouterCallKeyCallID)
+	}
+	if m.RouterCallKeySeqNum != 0 {
+		fw.WriteUint32(protocol.TagRouterCallKeySeqNum, m.RouterCallKeySeqNum)
+	}
+	if m.CallVariable1 != "" {
+		fw.WriteString(protocol.TagCallVariable1, m.CallVariable1)
+	}
+	if m.CallVariable2 != "" {
+		fw.WriteString(protocol.TagCallVariable2, m.CallVariable2)
+	}
+	if m.CallVariable3 != "" {
+		fw.WriteString(protocol.TagCallVariable3, m.CallVariable3)
+	}
+	if m.CallVariable4 != "" {
+		fw.WriteString(protocol.TagCallVariable4, m.CallVariable4)
+	}
+	if m.CallVariable5 != "" {
+		fw.WriteString(protocol.TagCallVariable5, m.CallVariable5)
+	}
+	if m.CallVariable6 != "" {
+		fw.WriteString(protocol.TagCallVariable6, m.CallVariable6)
+	}
+	if m.CallVariable7 != "" {
+		fw.WriteString(protocol.TagCallVariable7, m.CallVariable7)
+	}
+	if m.CallVariable8 != "" {
+		fw.WriteString(protocol.TagCallVariable8, m.CallVariable8)
+	}
+	if m.CallVariable9 != "" {
+		fw.WriteString(protocol.TagCallVariable9, m.CallVariable9)
+	}
+	if m.CallVariable10 != "" {
+		fw.WriteString(protocol.TagCallVariable10, m.CallVariable10)
+	}
+	if m.CallWrapupData != "" {
+		fw.WriteString(protocol.TagCallWrapupData, m.CallWrapupData)
+	}
+	fw.WriteNamedVariables(m.NamedVariables)
+	fw.WriteNamedArrays(m.NamedArrays)
+
+	// Write CallDevice repeated fields
+	for _, d := range m.Devices {
+		fw.WriteUint32(protocol.TagCallConnectionCallID, d.ConnectionCallID)
+		fw.WriteUint16(protocol.TagCallConnectionDeviceIDType, d.ConnectionDeviceIDType)
+		fw.WriteString(protocol.TagCallConnectionDeviceID, d.ConnectionDeviceID)
+		fw.WriteUint16(protocol.TagCallDeviceIDType, d.DeviceIDType)
+		fw.WriteString(protocol.TagCallDeviceID, d.DeviceID)
+		fw.WriteUint16(protocol.TagCallDeviceConnectionState, d.ConnectionState)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SnapshotCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.CallType = r.ReadUint16()
+	m.NumCTIClients = r.ReadUint16()
+	m.NumCallDevices = r.ReadUint16()
+	m.NumNamedVariables = r.ReadUint16()
+	m.NumNamedArrays = r.ReadUint16()
+	m.CalledPartyDisposition = r.ReadUint16()
+	m.CampaignID = r.ReadUint32()
+	m.QueryRuleID = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ANI = ff.GetString(protocol.TagANI)
+		m.UserToUserInfo = ff.GetString(protocol.TagUserToUserInfo)
+		m.DNIS = ff.GetString(protocol.TagDNIS)
+		m.DialedNumber = ff.GetString(protocol.TagDialedNumber)
+		m.CallerEnteredDigits = ff.GetString(protocol.TagCallerEnteredDigits)
+		m.RouterCallKeyDay = ff.GetUint32(protocol.TagRouterCallKeyDay)
+		m.RouterCallKeyCallID = ff.GetUint32(protocol.TagRouterCallKeyCallID)
+		m.RouterCallKeySeqNum = ff.GetUint32(protocol.TagRouterCallKeySeqNum)
+		m.CallVariable1 = ff.GetString(protocol.TagCallVariable1)
+		m.CallVariable2 = ff.GetString(protocol.TagCallVariable2)
+		m.CallVariable3 = ff.GetString(protocol.TagCallVariable3)
+		m.CallVariable4 = ff.GetString(protocol.TagCallVariable4)
+		m.CallVariable5 = ff.GetString(protocol.TagCallVariable5)
+		m.CallVariable6 = ff.GetString(protocol.TagCallVariable6)
+		m.CallVariable7 = ff.GetString(protocol.TagCallVariable7)
+		m.CallVariable8 = ff.GetString(protocol.TagCallVariable8)
+		m.CallVariable9 = ff.GetString(protocol.TagCallVariable9)
+		m.CallVariable10 = ff.GetString(protocol.TagCallVariable10)
+		m.CallWrapupData = ff.GetString(protocol.TagCallWrapupData)
+		m.NamedVariables = ff.GetNamedVariables()
+		m.NamedArrays = ff.GetNamedArrays()
+
+		// Devices are walked in order since optional fields may be absent
+		// from some devices; each Call Connection Call ID starts a new device.
+		p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+		for p.HasMore() {
+			tag, data, err := p.Next()
+			if err != nil {
+				return err
+			}
+			if tag == protocol.TagCallConnectionCallID {
+				m.Devices = append(m.Devices, CallDevice{ConnectionCallID: protocol.FieldUint32(data)})
+				continue
+			}
+			if len(m.Devices) == 0 {
+				continue
+			}
+
+			d := &m.Devices[len(m.Devices)-1]
+			switch tag {
+			case protocol.TagCallConnectionDeviceIDType:
+				d.ConnectionDeviceIDType = protocol.FieldUint16(data)
+			case protocol.TagCallConnectionDeviceID:
+				d.ConnectionDeviceID = protocol.FieldString(data)
+			case protocol.TagCallDeviceIDType:
+				d.DeviceIDType = protocol.FieldUint16(data)
+			case protocol.TagCallDeviceID:
+				d.DeviceID = protocol.FieldString(data)
+			case protocol.TagCallDeviceConnectionState:
+				d.ConnectionState = protocol.FieldUint16(data)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SnapshotCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// SnapshotDeviceReq is sent to retrieve the calls present at a device.
+// Protocol Version 24 - SNAPSHOT_DEVICE_REQ (MessageType = 84)
+type SnapshotDeviceReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+	Reserved     uint16 // Reserved (USHORT)
+
+	// Floating fields
+	AgentInstrument string // Tag 5 (max 64 bytes)
+}
+
+func (m *SnapshotDeviceReq) Type() uint32 {
+	return protocol.MsgTypeSnapshotDeviceReq
+}
+
+func (m *SnapshotDeviceReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SnapshotDeviceReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// SnapshotDeviceConf returns the calls present at a device.
+// Protocol Version 24 - SNAPSHOT_DEVICE_CONF (MessageType = 85)
+type SnapshotDeviceConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches SnapshotDeviceReq InvokeID (UINT)
+	NumCalls uint16 // Number of calls at the device (USHORT)
+	Reserved uint16 // Reserved (USHORT)
+
+	// Calls contains repeating call info (up to NumCalls)
+	Calls []DeviceCall
+}
+
+func (m *SnapshotDeviceConf) Type() uint32 {
+	return protocol.MsgTypeSnapshotDeviceConf
+}
+
+func (m *SnapshotDeviceConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(uint16(len(m.Calls)))
+	w.WriteUint16(m.Reserved)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Write DeviceCall repeated fields
+	fw := protocol.NewFloatingFieldWriter()
+	for _, c := range m.Calls {
+		fw.WriteUint32(protocol.TagCallConnectionCallID, c.ConnectionCallID)
+		fw.WriteUint16(protocol.TagCallConnectionDeviceIDType, c.ConnectionDeviceIDType)
+		fw.WriteString(protocol.TagCallConnectionDeviceID, c.ConnectionDeviceID)
+		fw.WriteUint16(protocol.TagCallState, c.CallState)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SnapshotDeviceConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.NumCalls = r.ReadUint16()
+	m.Reserved = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Calls are walked in order since optional fields may be absent from
+	// some calls; each Call Connection Call ID starts a new call.
+	p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+	for p.HasMore() {
+		tag, data, err := p.Next()
+		if err != nil {
+			return err
+		}
+		if tag == protocol.TagCallConnectionCallID {
+			m.Calls = append(m.Calls, DeviceCall{ConnectionCallID: protocol.FieldUint32(data)})
+			continue
+		}
+		if len(m.Calls) == 0 {
+			continue
+		}
+
+		c := &m.Calls[len(m.Calls)-1]
+		switch tag {
+		case protocol.TagCallConnectionDeviceIDType:
+			c.ConnectionDeviceIDType = protocol.FieldUint16(data)
+		case protocol.TagCallConnectionDeviceID:
+			c.ConnectionDeviceID = protocol.FieldString(data)
+		case protocol.TagCallState:
+			c.CallState = protocol.FieldUint16(data)
+		}
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SnapshotDeviceConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
@@ -0,0 +1,110 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&SnapshotCallReq{
+			InvokeID:               15,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ConnectionDeviceID:     "4001",
+		},
+		&SnapshotCallConf{
+			InvokeID:               15,
+			CallType:               1,
+			NumCTIClients:          1,
+			NumCallDevices:         2,
+			NumNamedVariables:      1,
+			NumNamedArrays:         2, // one per array element
+			CalledPartyDisposition: 2,
+			CampaignID:             3,
+			QueryRuleID:            4,
+			ANI:                    "5550000",
+			DNIS:                   "8000",
+			RouterCallKeyDay:       150000,
+			RouterCallKeyCallID:    77,
+			RouterCallKeySeqNum:    1,
+			CallVariable1:          "v1",
+			CallWrapupData:         "wrap",
+			NamedVariables:         map[string]string{"user.account": "42"},
+			NamedArrays:            map[string][]string{"user.items": {"a", "b"}},
+			Devices: []CallDevice{
+				{ConnectionCallID: 100, ConnectionDeviceIDType: 1, ConnectionDeviceID: "4001", DeviceIDType: 1, DeviceID: "4001", ConnectionState: 3},
+				{ConnectionCallID: 100, ConnectionDeviceIDType: 1, ConnectionDeviceID: "5550000", DeviceIDType: 2, DeviceID: "5550000", ConnectionState: 3},
+			},
+		},
+		&SnapshotDeviceReq{InvokeID: 16, PeripheralID: 5000, AgentInstrument: "4001"},
+		&SnapshotDeviceConf{
+			InvokeID: 16,
+			NumCalls: 2,
+			Calls: []DeviceCall{
+				{ConnectionCallID: 100, ConnectionDeviceIDType: 1, ConnectionDeviceID: "4001", CallState: 3},
+				{ConnectionCallID: 101, ConnectionDeviceIDType: 1, ConnectionDeviceID: "4001", CallState: 4},
+			},
+		},
+	})
+}
+
+// Devices that leave out optional fields must not shift the following
+// devices' fields onto them.
+func TestSnapshotCallConfDecodeSparseDevices(t *testing.T) {
+	fixed, err := (&SnapshotCallConf{InvokeID: 15}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteString(protocol.TagANI, "5550000")
+	fw.WriteUint32(protocol.TagCallConnectionCallID, 100)
+	fw.WriteUint16(protocol.TagCallConnectionDeviceIDType, 1)
+	fw.WriteUint16(protocol.TagCallDeviceConnectionState, 3)
+	fw.WriteUint32(protocol.TagCallConnectionCallID, 100)
+	fw.WriteString(protocol.TagCallConnectionDeviceID, "5550000")
+	fw.WriteUint16(protocol.TagCallDeviceIDType, 2)
+	fw.WriteString(protocol.TagCallDeviceID, "5550000")
+	fw.WriteUint16(protocol.TagCallDeviceConnectionState, 2)
+
+	var m SnapshotCallConf
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []CallDevice{
+		{ConnectionCallID: 100, ConnectionDeviceIDType: 1, ConnectionState: 3},
+		{ConnectionCallID: 100, ConnectionDeviceID: "5550000", DeviceIDType: 2, DeviceID: "5550000", ConnectionState: 2},
+	}
+	if m.ANI != "5550000" || !reflect.DeepEqual(m.Devices, want) {
+		t.Errorf("ANI %q, Devices =\n%+v\nwant 5550000 and\n%+v", m.ANI, m.Devices, want)
+	}
+}
+
+func TestSnapshotDeviceConfDecodeSparseCalls(t *testing.T) {
+	fixed, err := (&SnapshotDeviceConf{InvokeID: 16}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteUint32(protocol.TagCallConnectionCallID, 100)
+	fw.WriteUint16(protocol.TagCallState, 3)
+	fw.WriteUint32(protocol.TagCallConnectionCallID, 101)
+	fw.WriteString(protocol.TagCallConnectionDeviceID, "4001")
+	fw.WriteUint16(protocol.TagCallState, 4)
+
+	var m SnapshotDeviceConf
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DeviceCall{
+		{ConnectionCallID: 100, CallState: 3},
+		{ConnectionCallID: 101, ConnectionDeviceID: "4001", CallState: 4},
+	}
+	if !reflect.DeepEqual(m.Calls, want) {
+		t.Errorf("Calls =\n%+v\nwant\n%+v", m.Calls, want)
+	}
+}
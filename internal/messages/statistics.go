@@ -0,0 +1,419 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// QueueStatistics holds real-time statistics for one CSQ or skill group.
+type QueueStatistics struct {
+	ID              uint32 // CSQ ID (Tag 62) or skill group number (Tag 9)
+	CallsInQueue    uint32 // Tag 200
+	LongestWaitTime uint32 // Tag 201 - seconds the oldest queued call has waited
+	AgentsLoggedIn  uint32 // Tag 202
+	AgentsReady     uint32 // Tag 203
+	AgentsNotReady  uint32 // Tag 204
+	AgentsTalking   uint32 // Tag 205
+	AgentsWork      uint32 // Tag 206
+}
+
+// writeQueueStatistics writes statistics records as repeated floating fields,
+// identifying each record with idTag.
+func writeQueueStatistics(fw *protocol.FloatingFieldWriter, idTag uint16, records []QueueStatistics) {
+	for _, s := range records {
+		fw.WriteUint32(idTag, s.ID)
+		fw.WriteUint32(protocol.TagCallsInQueue, s.CallsInQueue)
+		fw.WriteUint32(protocol.TagLongestWaitTime, s.LongestWaitTime)
+		fw.WriteUint32(protocol.TagAgentsLoggedIn, s.AgentsLoggedIn)
+		fw.WriteUint32(protocol.TagAgentsReady, s.AgentsReady)
+		fw.WriteUint32(protocol.TagAgentsNotReady, s.AgentsNotReady)
+		fw.WriteUint32(protocol.TagAgentsTalking, s.AgentsTalking)
+		fw.WriteUint32(protocol.TagAgentsWork, s.AgentsWork)
+	}
+}
+
+// readQueueStatistics builds statistics records from repeated floating
+// fields. Fields are walked in order since counters may be absent from some
+// records; each occurrence of idTag starts a new record.
+func readQueueStatistics(data []byte, idTag uint16) ([]QueueStatistics, error) {
+	var records []QueueStatistics
+	p := protocol.NewFloatingFieldParser(data)
+	for p.HasMore() {
+		tag, data, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		if tag == idTag {
+			records = append(records, QueueStatistics{ID: protocol.FieldUint32(data)})
+			continue
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		rec := &records[len(records)-1]
+		switch tag {
+		case protocol.TagCallsInQueue:
+			rec.CallsInQueue = protocol.FieldUint32(data)
+		case protocol.TagLongestWaitTime:
+			rec.LongestWaitTime = protocol.FieldUint32(data)
+		case protocol.TagAgentsLoggedIn:
+			rec.AgentsLoggedIn = protocol.FieldUint32(data)
+		case protocol.TagAgentsReady:
+			rec.AgentsReady = protocol.FieldUint32(data)
+		case protocol.TagAgentsNotReady:
+			rec.AgentsNotReady = protocol.FieldUint32(data)
+		case protocol.TagAgentsTalking:
+			rec.AgentsTalking = protocol.FieldUint32(data)
+		case protocol.TagAgentsWork:
+			rec.AgentsWork = protocol.FieldUint32(data)
+		}
+	}
+	return records, nil
+}
+
+// QueryQueueStatisticsReq is sent to retrieve real-time statistics for CSQs.
+// Protocol Version 24 - QUERY_QUEUE_STATISTICS_REQ (MessageType = 223)
+type QueryQueueStatisticsReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+	NumCSQs      uint16 // Number of CSQs requested (USHORT) - derived from CSQIDs
+
+	// CSQIDs lists the CSQs to report on (Tag 62, repeated). Empty requests all CSQs.
+	CSQIDs []uint32
+}
+
+func (m *QueryQueueStatisticsReq) Type() uint32 {
+	return protocol.MsgTypeQueryQueueStatisticsReq
+}
+
+func (m *QueryQueueStatisticsReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint16(uint16(len(m.CSQIDs)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	for _, id := range m.CSQIDs {
+		fw.WriteUint32(protocol.TagCSQID, id)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryQueueStatisticsReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.NumCSQs = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.CSQIDs = ff.GetAllUint32(protocol.TagCSQID)
+	}
+
+	return nil
+}
+
+// QueryQueueStatisticsConf returns real-time statistics per CSQ.
+// Protocol Version 24 - QUERY_QUEUE_STATISTICS_CONF (MessageType = 224)
+type QueryQueueStatisticsConf struct {
+	// Fixed Part
+	InvokeID   uint32 // Matches QueryQueueStatisticsReq InvokeID (UINT)
+	NumRecords uint16 // Number of statistics records (USHORT)
+
+	// Queues contains one record per CSQ (ID is the CSQ ID)
+	Queues []QueueStatistics
+}
+
+func (m *QueryQueueStatisticsConf) Type() uint32 {
+	return protocol.MsgTypeQueryQueueStatisticsConf
+}
+
+func (m *QueryQueueStatisticsConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(uint16(len(m.Queues)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	writeQueueStatistics(fw, protocol.TagCSQID, m.Queues)
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryQueueStatisticsConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.NumRecords = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	var err error
+	m.Queues, err = readQueueStatistics(r.RemainingBytes(), protocol.TagCSQID)
+	return err
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *QueryQueueStatisticsConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// QuerySummaryStatisticsReq is sent to retrieve contact center wide statistics.
+// Protocol Version 24 - QUERY_SUMMARY_STATISTICS_REQ (MessageType = 225)
+type QuerySummaryStatisticsReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+}
+
+func (m *QuerySummaryStatisticsReq) Type() uint32 {
+	return protocol.MsgTypeQuerySummaryStatisticsReq
+}
+
+func (m *QuerySummaryStatisticsReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *QuerySummaryStatisticsReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	return r.Error()
+}
+
+// QuerySummaryStatisticsConf returns contact center totals and per skill group statistics.
+// Protocol Version 24 - QUERY_SUMMARY_STATISTICS_CONF (MessageType = 226)
+type QuerySummaryStatisticsConf struct {
+	// Fixed Part
+	InvokeID            uint32 // Matches QuerySummaryStatisticsReq InvokeID (UINT)
+	TotalCallsInQueue   uint32 // Calls waiting in all queues (UINT)
+	LongestWaitTime     uint32 // Longest wait of any queued call in seconds (UINT)
+	TotalAgentsLoggedIn uint32 // Agents logged in (UINT)
+	TotalAgentsReady    uint32 // Agents ready (UINT)
+	TotalAgentsNotReady uint32 // Agents not ready (UINT)
+	TotalAgentsTalking  uint32 // Agents talking (UINT)
+	TotalAgentsWork     uint32 // Agents in work state (UINT)
+	NumSkillGroups      uint16 // Number of skill group records (USHORT)
+
+	// SkillGroups contains one record per skill group (ID is the skill group number)
+	SkillGroups []QueueStatistics
+}
+
+func (m *QuerySummaryStatisticsConf) Type() uint32 {
+	return protocol.MsgTypeQuerySummaryStatisticsConf
+}
+
+func (m *QuerySummaryStatisticsConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.TotalCallsInQueue)
+	w.WriteUint32(m.LongestWaitTime)
+	w.WriteUint32(m.TotalAgentsLoggedIn)
+	w.WriteUint32(m.TotalAgentsReady)
+	w.WriteUint32(m.TotalAgentsNotReady)
+	w.WriteUint32(m.TotalAgentsTalking)
+	w.WriteUint32(m.TotalAgentsWork)
+	w.WriteUint16(uint16(len(m.SkillGroups)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	writeQueueStatistics(fw, protocol.TagSkillGroupNumber, m.SkillGroups)
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QuerySummaryStatisticsConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.TotalCallsInQueue = r.ReadUint32()
+	m.LongestWaitTime = r.ReadUint32()
+	m.TotalAgentsLoggedIn = r.ReadUint32()
+	m.TotalAgentsReady = r.ReadUint32()
+	m.TotalAgentsNotReady = r.ReadUint32()
+	m.TotalAgentsTalking = r.ReadUint32()
+	m.TotalAgentsWork = r.ReadUint32()
+	m.NumSkillGroups = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	var err error
+	m.SkillGroups, err = readQueueStatistics(r.RemainingBytes(), protocol.TagSkillGroupNumber)
+	return err
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *QuerySummaryStatisticsConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// QueryAgentQueueStatisticsReq is sent to retrieve statistics for the CSQs an agent serves.
+// Protocol Version 24 - QUERY_AGENT_QUEUE_STATISTICS_REQ (MessageType = 239)
+type QueryAgentQueueStatisticsReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+
+	// Floating fields
+	AgentID string // Tag 4 (max 12 bytes)
+}
+
+func (m *QueryAgentQueueStatisticsReq) Type() uint32 {
+	return protocol.MsgTypeQueryAgentQueueStatisticsReq
+}
+
+func (m *QueryAgentQueueStatisticsReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryAgentQueueStatisticsReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+	}
+
+	return nil
+}
+
+// QueryAgentQueueStatisticsConf returns statistics for each CSQ an agent serves.
+// Protocol Version 24 - QUERY_AGENT_QUEUE_STATISTICS_CONF (MessageType = 240)
+type QueryAgentQueueStatisticsConf struct {
+	// Fixed Part
+	InvokeID   uint32 // Matches QueryAgentQueueStatisticsReq InvokeID (UINT)
+	NumRecords uint16 // Number of statistics records (USHORT)
+
+	// Floating fields
+	AgentID string // Tag 4 (max 12 bytes)
+
+	// Queues contains one record per CSQ the agent serves (ID is the CSQ ID)
+	Queues []QueueStatistics
+}
+
+func (m *QueryAgentQueueStatisticsConf) Type() uint32 {
+	return protocol.MsgTypeQueryAgentQueueStatisticsConf
+}
+
+func (m *QueryAgentQueueStatisticsConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(uint16(len(m.Queues)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentID != "" {
+		fw.WriteString(protocol.TagAgentID, m.AgentID)
+	}
+	writeQueueStatistics(fw, protocol.TagCSQID, m.Queues)
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *QueryAgentQueueStatisticsConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.NumRecords = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentID = ff.GetString(protocol.TagAgentID)
+		m.Queues, err = readQueueStatistics(r.RemainingBytes(), protocol.TagCSQID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *QueryAgentQueueStatisticsConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
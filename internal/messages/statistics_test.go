@@ -0,0 +1,98 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestStatisticsRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&QueryQueueStatisticsReq{InvokeID: 17, PeripheralID: 5000, NumCSQs: 2, CSQIDs: []uint32{1, 2}},
+		&QueryQueueStatisticsConf{
+			InvokeID:   17,
+			NumRecords: 2,
+			Queues: []QueueStatistics{
+				{ID: 1, CallsInQueue: 3, LongestWaitTime: 60, AgentsLoggedIn: 5, AgentsReady: 1, AgentsNotReady: 1, AgentsTalking: 2, AgentsWork: 1},
+				{ID: 2, CallsInQueue: 0, AgentsLoggedIn: 2, AgentsReady: 2},
+			},
+		},
+		&QuerySummaryStatisticsReq{InvokeID: 18, PeripheralID: 5000},
+		&QuerySummaryStatisticsConf{
+			InvokeID:            18,
+			TotalCallsInQueue:   3,
+			LongestWaitTime:     60,
+			TotalAgentsLoggedIn: 7,
+			TotalAgentsReady:    3,
+			TotalAgentsNotReady: 1,
+			TotalAgentsTalking:  2,
+			TotalAgentsWork:     1,
+			NumSkillGroups:      1,
+			SkillGroups: []QueueStatistics{
+				{ID: 10, CallsInQueue: 3, LongestWaitTime: 60, AgentsLoggedIn: 7, AgentsReady: 3, AgentsNotReady: 1, AgentsTalking: 2, AgentsWork: 1},
+			},
+		},
+		&QueryAgentQueueStatisticsReq{InvokeID: 19, PeripheralID: 5000, AgentID: "1001"},
+		&QueryAgentQueueStatisticsConf{
+			InvokeID:   19,
+			NumRecords: 1,
+			AgentID:    "1001",
+			Queues: []QueueStatistics{
+				{ID: 1, CallsInQueue: 3, LongestWaitTime: 60, AgentsLoggedIn: 5, AgentsReady: 1},
+			},
+		},
+	})
+}
+
+// Records that leave out counters must not shift the following records'
+// counters onto them.
+func TestQueryQueueStatisticsConfDecodeSparseRecords(t *testing.T) {
+	fixed, err := (&QueryQueueStatisticsConf{InvokeID: 17}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteUint32(protocol.TagCSQID, 1)
+	fw.WriteUint32(protocol.TagAgentsReady, 4)
+	fw.WriteUint32(protocol.TagCSQID, 2)
+	fw.WriteUint32(protocol.TagCallsInQueue, 3)
+	fw.WriteUint32(protocol.TagLongestWaitTime, 60)
+	fw.WriteUint32(protocol.TagCSQID, 3)
+	fw.WriteUint32(protocol.TagAgentsWork, 1)
+
+	var m QueryQueueStatisticsConf
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []QueueStatistics{
+		{ID: 1, AgentsReady: 4},
+		{ID: 2, CallsInQueue: 3, LongestWaitTime: 60},
+		{ID: 3, AgentsWork: 1},
+	}
+	if !reflect.DeepEqual(m.Queues, want) {
+		t.Errorf("Queues =\n%+v\nwant\n%+v", m.Queues, want)
+	}
+}
+
+func TestQueryAgentQueueStatisticsConfDecodeSparseRecords(t *testing.T) {
+	fixed, err := (&QueryAgentQueueStatisticsConf{InvokeID: 19}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteString(protocol.TagAgentID, "1001")
+	fw.WriteUint32(protocol.TagCSQID, 1)
+	fw.WriteUint32(protocol.TagCSQID, 2)
+	fw.WriteUint32(protocol.TagAgentsTalking, 2)
+
+	var m QueryAgentQueueStatisticsConf
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []QueueStatistics{{ID: 1}, {ID: 2, AgentsTalking: 2}}
+	if m.AgentID != "1001" || !reflect.DeepEqual(m.Queues, want) {
+		t.Errorf("AgentID %q, Queues =\n%+v\nwant 1001 and\n%+v", m.AgentID, m.Queues, want)
+	}
+}
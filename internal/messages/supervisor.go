@@ -0,0 +1,317 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// SupervisorAssistReq is sent by an agent to request assistance from their supervisor.
+// Protocol Version 24 - SUPERVISOR_ASSIST_REQ (MessageType = 118)
+type SupervisorAssistReq struct {
+	// Fixed Part
+	InvokeID               uint32 // Client-assigned request ID (UINT)
+	PeripheralID           uint32 // Peripheral ID (UINT)
+	ConnectionCallID       uint32 // Agent's call ID (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+	AgentInstrument    string // Tag 5 (max 64 bytes)
+}
+
+func (m *SupervisorAssistReq) Type() uint32 {
+	return protocol.MsgTypeSupervisorAssistReq
+}
+
+func (m *SupervisorAssistReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+	if m.AgentInstrument != "" {
+		fw.WriteString(protocol.TagAgentInstrument, m.AgentInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SupervisorAssistReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+		m.AgentInstrument = ff.GetString(protocol.TagAgentInstrument)
+	}
+
+	return nil
+}
+
+// SupervisorAssistConf confirms a SUPERVISOR_ASSIST_REQ and identifies the
+// new connection to the supervisor.
+// Protocol Version 24 - SUPERVISOR_ASSIST_CONF (MessageType = 119)
+type SupervisorAssistConf struct {
+	// Fixed Part
+	InvokeID               uint32 // Matches SupervisorAssistReq InvokeID (UINT)
+	ConnectionCallID       uint32 // New call ID (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	LineHandle             uint16 // Line handle (USHORT)
+	LineType               uint16 // Line type (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+}
+
+func (m *SupervisorAssistConf) Type() uint32 {
+	return protocol.MsgTypeSupervisorAssistConf
+}
+
+func (m *SupervisorAssistConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.LineHandle)
+	w.WriteUint16(m.LineType)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SupervisorAssistConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.LineHandle = r.ReadUint16()
+	m.LineType = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SupervisorAssistConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
+
+// SuperviseCallReq is sent by a supervisor to monitor, coach, barge into or
+// intercept an agent's call.
+// Protocol Version 24 - SUPERVISE_CALL_REQ (MessageType = 124)
+type SuperviseCallReq struct {
+	// Fixed Part
+	InvokeID                         uint32 // Client-assigned request ID (UINT)
+	PeripheralID                     uint32 // Peripheral ID (UINT)
+	AgentConnectionCallID            uint32 // Agent's call ID (UINT)
+	AgentConnectionDeviceIDType      uint16 // Agent's device ID type (USHORT)
+	SupervisorConnectionCallID       uint32 // Supervisor's call ID, if already connected (UINT)
+	SupervisorConnectionDeviceIDType uint16 // Supervisor's device ID type (USHORT)
+	SupervisoryAction                uint16 // SupervisorAction value (USHORT)
+
+	// Floating fields
+	AgentConnectionDeviceID      string // Tag 197
+	SupervisorConnectionDeviceID string // Tag 198
+	SupervisorInstrument         string // Tag 199 (max 64 bytes)
+}
+
+func (m *SuperviseCallReq) Type() uint32 {
+	return protocol.MsgTypeSuperviseCallReq
+}
+
+func (m *SuperviseCallReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.AgentConnectionCallID)
+	w.WriteUint16(m.AgentConnectionDeviceIDType)
+	w.WriteUint32(m.SupervisorConnectionCallID)
+	w.WriteUint16(m.SupervisorConnectionDeviceIDType)
+	w.WriteUint16(m.SupervisoryAction)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.AgentConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagAgentConnectionDeviceID, m.AgentConnectionDeviceID)
+	}
+	if m.SupervisorConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagSupervisorConnectionDeviceID, m.SupervisorConnectionDeviceID)
+	}
+	if m.SupervisorInstrument != "" {
+		fw.WriteString(protocol.TagSupervisorInstrument, m.SupervisorInstrument)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SuperviseCallReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.AgentConnectionCallID = r.ReadUint32()
+	m.AgentConnectionDeviceIDType = r.ReadUint16()
+	m.SupervisorConnectionCallID = r.ReadUint32()
+	m.SupervisorConnectionDeviceIDType = r.ReadUint16()
+	m.SupervisoryAction = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.AgentConnectionDeviceID = ff.GetString(protocol.TagAgentConnectionDeviceID)
+		m.SupervisorConnectionDeviceID = ff.GetString(protocol.TagSupervisorConnectionDeviceID)
+		m.SupervisorInstrument = ff.GetString(protocol.TagSupervisorInstrument)
+	}
+
+	return nil
+}
+
+// ActionName returns a human-readable name for the supervisory action.
+func (m *SuperviseCallReq) ActionName() string {
+	return SupervisorActionName(SupervisorAction(m.SupervisoryAction))
+}
+
+// SuperviseCallConf confirms a SUPERVISE_CALL_REQ and identifies the
+// supervisor's connection to the call.
+// Protocol Version 24 - SUPERVISE_CALL_CONF (MessageType = 125)
+type SuperviseCallConf struct {
+	// Fixed Part
+	InvokeID               uint32 // Matches SuperviseCallReq InvokeID (UINT)
+	ConnectionCallID       uint32 // Supervisor's call ID (UINT)
+	ConnectionDeviceIDType uint16 // Device ID type (USHORT)
+	LineHandle             uint16 // Line handle (USHORT)
+	LineType               uint16 // Line type (USHORT)
+
+	// Floating fields
+	ConnectionDeviceID string // Tag 31
+}
+
+func (m *SuperviseCallConf) Type() uint32 {
+	return protocol.MsgTypeSuperviseCallConf
+}
+
+func (m *SuperviseCallConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.ConnectionCallID)
+	w.WriteUint16(m.ConnectionDeviceIDType)
+	w.WriteUint16(m.LineHandle)
+	w.WriteUint16(m.LineType)
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	// Add floating fields
+	fw := protocol.NewFloatingFieldWriter()
+	if m.ConnectionDeviceID != "" {
+		fw.WriteString(protocol.TagConnectionDeviceID, m.ConnectionDeviceID)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *SuperviseCallConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.ConnectionCallID = r.ReadUint32()
+	m.ConnectionDeviceIDType = r.ReadUint16()
+	m.LineHandle = r.ReadUint16()
+	m.LineType = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Parse floating fields
+	if r.Remaining() > 0 {
+		ff, err := protocol.ParseFloatingFields(r.RemainingBytes())
+		if err != nil {
+			return err
+		}
+		m.ConnectionDeviceID = ff.GetString(protocol.TagConnectionDeviceID)
+	}
+
+	return nil
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *SuperviseCallConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
@@ -0,0 +1,47 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"testing"
+)
+
+func TestSupervisorRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&SupervisorAssistReq{
+			InvokeID:               21,
+			PeripheralID:           5000,
+			ConnectionCallID:       100,
+			ConnectionDeviceIDType: 1,
+			ConnectionDeviceID:     "4001",
+			AgentInstrument:        "4001",
+		},
+		&SupervisorAssistConf{
+			InvokeID:               21,
+			ConnectionCallID:       102,
+			ConnectionDeviceIDType: 1,
+			LineHandle:             1,
+			LineType:               2,
+			ConnectionDeviceID:     "4001",
+		},
+		&SuperviseCallReq{
+			InvokeID:                         22,
+			PeripheralID:                     5000,
+			AgentConnectionCallID:            100,
+			AgentConnectionDeviceIDType:      1,
+			SupervisorConnectionCallID:       102,
+			SupervisorConnectionDeviceIDType: 1,
+			SupervisoryAction:                1,
+			AgentConnectionDeviceID:          "4001",
+			SupervisorConnectionDeviceID:     "4002",
+			SupervisorInstrument:             "4002",
+		},
+		&SuperviseCallConf{
+			InvokeID:               22,
+			ConnectionCallID:       103,
+			ConnectionDeviceIDType: 1,
+			LineHandle:             1,
+			LineType:               2,
+			ConnectionDeviceID:     "4002",
+		},
+	})
+}
@@ -0,0 +1,172 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+)
+
+// TeamMemberFlagSupervisor marks a team member as a supervisor of the team.
+const TeamMemberFlagSupervisor uint16 = 0x0001
+
+// TeamMember represents one agent on a team in TEAM_CONFIG_EVENT.
+type TeamMember struct {
+	AgentID string // Tag 4
+	Flags   uint16 // Tag 208 - TeamMemberFlag* bits
+}
+
+// IsSupervisor reports whether the member supervises the team.
+func (t TeamMember) IsSupervisor() bool {
+	return t.Flags&TeamMemberFlagSupervisor != 0
+}
+
+// TeamConfigReq is sent to request the configuration of the teams visible to
+// the session. The server answers with one TEAM_CONFIG_EVENT per team followed
+// by TEAM_CONFIG_CONF.
+// Protocol Version 24 - TEAM_CONFIG_REQ (MessageType = 242)
+type TeamConfigReq struct {
+	// Fixed Part
+	InvokeID     uint32 // Client-assigned request ID (UINT)
+	PeripheralID uint32 // Peripheral ID (UINT)
+	TeamID       uint32 // Team to report on, 0 for all teams (UINT)
+}
+
+func (m *TeamConfigReq) Type() uint32 {
+	return protocol.MsgTypeTeamConfigReq
+}
+
+func (m *TeamConfigReq) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.TeamID)
+	return w.Bytes(), w.Error()
+}
+
+func (m *TeamConfigReq) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.PeripheralID = r.ReadUint32()
+	m.TeamID = r.ReadUint32()
+	return r.Error()
+}
+
+// TeamConfigEvent reports the configuration of one team and its members.
+// Sent in response to TEAM_CONFIG_REQ and whenever a team changes.
+// Protocol Version 24 - TEAM_CONFIG_EVENT (MessageType = 243)
+type TeamConfigEvent struct {
+	// Fixed Part
+	PeripheralID    uint32 // Peripheral ID (UINT)
+	TeamID          uint32 // Team ID (UINT)
+	ConfigOperation uint16 // Configuration operation (USHORT)
+	NumMembers      uint16 // Number of team members (USHORT)
+
+	// Floating fields
+	TeamName string // Tag 207
+
+	// Members contains repeating team member info (up to NumMembers)
+	Members []TeamMember
+}
+
+func (m *TeamConfigEvent) Type() uint32 {
+	return protocol.MsgTypeTeamConfigEvent
+}
+
+func (m *TeamConfigEvent) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.PeripheralID)
+	w.WriteUint32(m.TeamID)
+	w.WriteUint16(m.ConfigOperation)
+	w.WriteUint16(uint16(len(m.Members)))
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	fw := protocol.NewFloatingFieldWriter()
+	if m.TeamName != "" {
+		fw.WriteString(protocol.TagTeamName, m.TeamName)
+	}
+
+	// Write TeamMember repeated fields
+	for _, member := range m.Members {
+		fw.WriteString(protocol.TagAgentID, member.AgentID)
+		fw.WriteUint16(protocol.TagTeamMemberFlags, member.Flags)
+	}
+
+	fixed := w.Bytes()
+	floating := fw.Bytes()
+	result := make([]byte, len(fixed)+len(floating))
+	copy(result, fixed)
+	copy(result[len(fixed):], floating)
+
+	return result, nil
+}
+
+func (m *TeamConfigEvent) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.PeripheralID = r.ReadUint32()
+	m.TeamID = r.ReadUint32()
+	m.ConfigOperation = r.ReadUint16()
+	m.NumMembers = r.ReadUint16()
+
+	if err := r.Error(); err != nil {
+		return err
+	}
+
+	// Fields are walked in order since a member may be sent without flags;
+	// each Agent ID starts a new member.
+	p := protocol.NewFloatingFieldParser(r.RemainingBytes())
+	for p.HasMore() {
+		tag, data, err := p.Next()
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case protocol.TagTeamName:
+			m.TeamName = protocol.FieldString(data)
+		case protocol.TagAgentID:
+			m.Members = append(m.Members, TeamMember{AgentID: protocol.FieldString(data)})
+		case protocol.TagTeamMemberFlags:
+			if len(m.Members) > 0 {
+				m.Members[len(m.Members)-1].Flags = protocol.FieldUint16(data)
+			}
+		}
+	}
+
+	return nil
+}
+
+// OperationName returns a human-readable name for the config operation.
+func (m *TeamConfigEvent) OperationName() string {
+	return ConfigOperationName(ConfigOperation(m.ConfigOperation))
+}
+
+// TeamConfigConf completes a TEAM_CONFIG_REQ after all TEAM_CONFIG_EVENTs were sent.
+// Protocol Version 24 - TEAM_CONFIG_CONF (MessageType = 244)
+type TeamConfigConf struct {
+	// Fixed Part
+	InvokeID uint32 // Matches TeamConfigReq InvokeID (UINT)
+	NumTeams uint16 // Number of TEAM_CONFIG_EVENTs sent (USHORT)
+}
+
+func (m *TeamConfigConf) Type() uint32 {
+	return protocol.MsgTypeTeamConfigConf
+}
+
+func (m *TeamConfigConf) Encode() ([]byte, error) {
+	w := protocol.NewFixedFieldWriter()
+	w.WriteUint32(m.InvokeID)
+	w.WriteUint16(m.NumTeams)
+	return w.Bytes(), w.Error()
+}
+
+func (m *TeamConfigConf) Decode(data []byte) error {
+	r := protocol.NewFixedFieldReader(data)
+	m.InvokeID = r.ReadUint32()
+	m.NumTeams = r.ReadUint16()
+	return r.Error()
+}
+
+// GetInvokeID returns the InvokeID of the confirmed request.
+func (m *TeamConfigConf) GetInvokeID() uint32 {
+	return m.InvokeID
+}
@@ -0,0 +1,48 @@
+package messages
+
+import (
+	"ctiservice/internal/protocol"
+	"reflect"
+	"testing"
+)
+
+func TestTeamRoundTrip(t *testing.T) {
+	testRoundTrips(t, []protocol.Message{
+		&TeamConfigReq{InvokeID: 20, PeripheralID: 5000, TeamID: 3},
+		&TeamConfigEvent{
+			PeripheralID:    5000,
+			TeamID:          3,
+			ConfigOperation: uint16(ConfigOperationAdd),
+			NumMembers:      2,
+			TeamName:        "Sales",
+			Members: []TeamMember{
+				{AgentID: "1001", Flags: 0},
+				{AgentID: "2001", Flags: 1},
+			},
+		},
+		&TeamConfigConf{InvokeID: 20, NumTeams: 1},
+	})
+}
+
+// A member sent without flags must not take the next member's flags.
+func TestTeamConfigEventDecodeSparseMembers(t *testing.T) {
+	fixed, err := (&TeamConfigEvent{PeripheralID: 5000, TeamID: 3}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := protocol.NewFloatingFieldWriter()
+	fw.WriteString(protocol.TagTeamName, "Sales")
+	fw.WriteString(protocol.TagAgentID, "1001")
+	fw.WriteString(protocol.TagAgentID, "2001")
+	fw.WriteUint16(protocol.TagTeamMemberFlags, 1)
+
+	var m TeamConfigEvent
+	if err := m.Decode(append(fixed, fw.Bytes()...)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []TeamMember{{AgentID: "1001"}, {AgentID: "2001", Flags: 1}}
+	if m.TeamName != "Sales" || !reflect.DeepEqual(m.Members, want) {
+		t.Errorf("TeamName %q, Members =\n%+v\nwant Sales and\n%+v", m.TeamName, m.Members, want)
+	}
+}
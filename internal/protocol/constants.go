@@ -166,9 +166,14 @@ const (
 	ConfigMaskCSQ                 uint32 = 0x00000004 // CONFIG_CSQ_EVENT (skill group/queue)
 	ConfigMaskService             uint32 = 0x00000008 // CONFIG service events
 	ConfigMaskBeginEnd            uint32 = 0x00000010 // CONFIG_BEGIN/END_EVENT
+	ConfigMaskApplication         uint32 = 0x00000020 // CONFIG_APPLICATION_EVENT
 	ConfigMaskAll                 uint32 = 0xFFFFFFFF // All config events
 )
 
+// ConfigTypeAll is the CONFIG_REQUEST_EVENT ConfigType requesting every
+// configuration type. ConfigType is a USHORT carrying the ConfigMask* bits.
+const ConfigTypeAll uint16 = 0xFFFF
+
 // Agent state values.
 const (
 	AgentStateLoggedOut   uint16 = 0
@@ -224,6 +229,36 @@ const (
 	StatusInternalError       uint32 = 10
 )
 
+// StatusName returns a human-readable name for a failure status code.
+func StatusName(status uint32) string {
+	switch status {
+	case StatusSuccess:
+		return "Success"
+	case StatusInvalidRequest:
+		return "InvalidRequest"
+	case StatusInvalidState:
+		return "InvalidState"
+	case StatusInvalidSession:
+		return "InvalidSession"
+	case StatusInvalidService:
+		return "InvalidService"
+	case StatusInvalidCallID:
+		return "InvalidCallID"
+	case StatusInvalidDeviceID:
+		return "InvalidDeviceID"
+	case StatusResourceBusy:
+		return "ResourceBusy"
+	case StatusResourceUnavailable:
+		return "ResourceUnavailable"
+	case StatusProtocolError:
+		return "ProtocolError"
+	case StatusInternalError:
+		return "InternalError"
+	default:
+		return "Unknown"
+	}
+}
+
 // Floating field tag IDs.
 const (
 	TagClientID             uint16 = 1
@@ -275,8 +310,23 @@ const (
 	TagSecondaryDeviceID     uint16 = 47
 	TagPrimaryCallID         uint16 = 48
 	TagSecondaryCallID       uint16 = 49
+	TagCallConnectionCallID       uint16 = 56
+	TagCallConnectionDeviceIDType uint16 = 57
+	TagCallConnectionDeviceID     uint16 = 58
+	TagCallDeviceIDType           uint16 = 59
+	TagCallDeviceID               uint16 = 60
+	TagCallDeviceConnectionState  uint16 = 61
+	TagCallState                  uint16 = 75
+	TagDTMFString           uint16 = 67
+	TagLineHandle           uint16 = 70
+	TagLineType             uint16 = 71
 	TagRouterCallKeyDay     uint16 = 72
 	TagRouterCallKeyCallID  uint16 = 73
+	TagAuthorizationCode    uint16 = 77
+	TagAccountCode          uint16 = 78
+	TagApplicationPathID    uint16 = 90
+	TagAgentPassword        uint16 = 99
+	TagSkillGroupState      uint16 = 115
 	TagRouterCallKeySeqNum  uint16 = 214
 	TagNamedVariable        uint16 = 82
 	TagNamedArray           uint16 = 83
@@ -310,6 +360,45 @@ const (
 	TagAgentPeripheralID     uint16 = 194
 	TagAgentPeripheralNumber uint16 = 195
 	TagConfigOperation       uint16 = 196
+	// SUPERVISE_CALL_REQ device fields. Provisional: not yet confirmed
+	// against the GED-188 floating field tag table.
+	TagAgentConnectionDeviceID      uint16 = 197
+	TagSupervisorConnectionDeviceID uint16 = 198
+	TagSupervisorInstrument         uint16 = 199
+	// Queue statistics counters. Provisional: not yet confirmed against the
+	// GED-188 floating field tag table.
+	TagCallsInQueue                 uint16 = 200
+	TagLongestWaitTime              uint16 = 201
+	TagAgentsLoggedIn               uint16 = 202
+	TagAgentsReady                  uint16 = 203
+	TagAgentsNotReady               uint16 = 204
+	TagAgentsTalking                uint16 = 205
+	TagAgentsWork                   uint16 = 206
+	// TEAM_CONFIG_EVENT fields. Provisional: not yet confirmed against the
+	// GED-188 floating field tag table.
+	TagTeamName                     uint16 = 207
+	TagTeamMemberFlags              uint16 = 208
+	// Config key and CONFIG_APPLICATION_EVENT fields. Provisional: not yet
+	// confirmed against the GED-188 floating field tag table.
+	TagConfigKey                    uint16 = 209
+	TagApplicationID                uint16 = 210
+	TagApplicationName              uint16 = 211
+	TagApplicationEnabled           uint16 = 212
+	// CONFIG_DEVICE_EVENT device type. Provisional: not yet confirmed
+	// against the GED-188 floating field tag table.
+	TagDeviceType                   uint16 = 213
+	// RTP media addresses. Provisional: not yet confirmed against the
+	// GED-188 floating field tag table.
+	TagSendingAddress               uint16 = 215
+	TagSendingPort                  uint16 = 216
+	TagReceivingAddress             uint16 = 217
+	TagReceivingPort                uint16 = 218
+	// CALL_DIVERTED_EVENT diverting device. Provisional: not yet confirmed
+	// against the GED-188 floating field tag table.
+	TagDivertingDeviceID            uint16 = 219
+	// CONFIG_AGENT_EVENT ICM agent ID. Provisional: not yet confirmed
+	// against the GED-188 floating field tag table.
+	TagICMAgentID                   uint16 = 220
 )
 
 // Header size in bytes.
@@ -369,6 +458,18 @@ func MessageTypeName(msgType uint32) string {
 		return "END_CALL_EVENT"
 	case MsgTypeCallDataUpdateEvent:
 		return "CALL_DATA_UPDATE_EVENT"
+	case MsgTypeSetCallDataReq:
+		return "SET_CALL_DATA_REQ"
+	case MsgTypeSetCallDataConf:
+		return "SET_CALL_DATA_CONF"
+	case MsgTypeQueryAgentStateReq:
+		return "QUERY_AGENT_STATE_REQ"
+	case MsgTypeQueryAgentStateConf:
+		return "QUERY_AGENT_STATE_CONF"
+	case MsgTypeSetAgentStateReq:
+		return "SET_AGENT_STATE_REQ"
+	case MsgTypeSetAgentStateConf:
+		return "SET_AGENT_STATE_CONF"
 	case MsgTypeAgentStateEvent:
 		return "AGENT_STATE_EVENT"
 	case MsgTypeSystemEvent:
@@ -403,8 +504,80 @@ func MessageTypeName(msgType uint32) string {
 		return "RETRIEVE_CALL_REQ"
 	case MsgTypeRetrieveCallConf:
 		return "RETRIEVE_CALL_CONF"
+	case MsgTypeAlternateCallReq:
+		return "ALTERNATE_CALL_REQ"
+	case MsgTypeAlternateCallConf:
+		return "ALTERNATE_CALL_CONF"
+	case MsgTypeReconnectCallReq:
+		return "RECONNECT_CALL_REQ"
+	case MsgTypeReconnectCallConf:
+		return "RECONNECT_CALL_CONF"
+	case MsgTypeAnswerCallReq:
+		return "ANSWER_CALL_REQ"
+	case MsgTypeAnswerCallConf:
+		return "ANSWER_CALL_CONF"
+	case MsgTypeClearCallReq:
+		return "CLEAR_CALL_REQ"
+	case MsgTypeClearCallConf:
+		return "CLEAR_CALL_CONF"
+	case MsgTypeClearConnectionReq:
+		return "CLEAR_CONNECTION_REQ"
+	case MsgTypeClearConnectionConf:
+		return "CLEAR_CONNECTION_CONF"
+	case MsgTypeMakeCallReq:
+		return "MAKE_CALL_REQ"
+	case MsgTypeMakeCallConf:
+		return "MAKE_CALL_CONF"
+	case MsgTypeSendDTMFSignalReq:
+		return "SEND_DTMF_SIGNAL_REQ"
+	case MsgTypeSendDTMFSignalConf:
+		return "SEND_DTMF_SIGNAL_CONF"
+	case MsgTypeBadCallReq:
+		return "BAD_CALL_REQ"
+	case MsgTypeBadCallConf:
+		return "BAD_CALL_CONF"
+	case MsgTypeQueryDeviceInfoReq:
+		return "QUERY_DEVICE_INFO_REQ"
+	case MsgTypeQueryDeviceInfoConf:
+		return "QUERY_DEVICE_INFO_CONF"
+	case MsgTypeSnapshotCallReq:
+		return "SNAPSHOT_CALL_REQ"
+	case MsgTypeSnapshotCallConf:
+		return "SNAPSHOT_CALL_CONF"
+	case MsgTypeSnapshotDeviceReq:
+		return "SNAPSHOT_DEVICE_REQ"
+	case MsgTypeSnapshotDeviceConf:
+		return "SNAPSHOT_DEVICE_CONF"
+	case MsgTypeControlFailureConf:
+		return "CONTROL_FAILURE_CONF"
+	case MsgTypeSupervisorAssistReq:
+		return "SUPERVISOR_ASSIST_REQ"
+	case MsgTypeSupervisorAssistConf:
+		return "SUPERVISOR_ASSIST_CONF"
+	case MsgTypeSuperviseCallReq:
+		return "SUPERVISE_CALL_REQ"
+	case MsgTypeSuperviseCallConf:
+		return "SUPERVISE_CALL_CONF"
 	case MsgTypeSupervisorAssistEvent:
 		return "SUPERVISOR_ASSIST_EVENT"
+	case MsgTypeQueryQueueStatisticsReq:
+		return "QUERY_QUEUE_STATISTICS_REQ"
+	case MsgTypeQueryQueueStatisticsConf:
+		return "QUERY_QUEUE_STATISTICS_CONF"
+	case MsgTypeQuerySummaryStatisticsReq:
+		return "QUERY_SUMMARY_STATISTICS_REQ"
+	case MsgTypeQuerySummaryStatisticsConf:
+		return "QUERY_SUMMARY_STATISTICS_CONF"
+	case MsgTypeQueryAgentQueueStatisticsReq:
+		return "QUERY_AGENT_QUEUE_STATISTICS_REQ"
+	case MsgTypeQueryAgentQueueStatisticsConf:
+		return "QUERY_AGENT_QUEUE_STATISTICS_CONF"
+	case MsgTypeTeamConfigReq:
+		return "TEAM_CONFIG_REQ"
+	case MsgTypeTeamConfigEvent:
+		return "TEAM_CONFIG_EVENT"
+	case MsgTypeTeamConfigConf:
+		return "TEAM_CONFIG_CONF"
 	case MsgTypeConfigAgentEvent:
 		return "CONFIG_AGENT_EVENT"
 	case MsgTypeConfigDeviceEvent:
@@ -417,6 +590,12 @@ func MessageTypeName(msgType uint32) string {
 		return "CONFIG_END_EVENT"
 	case MsgTypeConfigRequestEvent:
 		return "CONFIG_REQUEST_EVENT"
+	case MsgTypeConfigRequestKeyEvent:
+		return "CONFIG_REQUEST_KEY_EVENT"
+	case MsgTypeConfigKeyEvent:
+		return "CONFIG_KEY_EVENT"
+	case MsgTypeConfigApplicationEvent:
+		return "CONFIG_APPLICATION_EVENT"
 	default:
 		return "UNKNOWN"
 	}
@@ -1,8 +1,10 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"sort"
 )
 
 // FloatingFieldParser parses floating fields from message data.
@@ -122,10 +124,22 @@ func (f *FloatingFields) GetBytes(tag uint16) []byte {
 
 // GetString returns a null-terminated string field.
 func (f *FloatingFields) GetString(tag uint16) string {
-	data := f.fields[tag]
-	if data == nil {
-		return ""
-	}
+	return FieldString(f.fields[tag])
+}
+
+// GetUint16 returns a uint16 field.
+func (f *FloatingFields) GetUint16(tag uint16) uint16 {
+	return FieldUint16(f.fields[tag])
+}
+
+// GetUint32 returns a uint32 field.
+func (f *FloatingFields) GetUint32(tag uint16) uint32 {
+	return FieldUint32(f.fields[tag])
+}
+
+// FieldString decodes the data of a null-terminated string field, as
+// returned by FloatingFieldParser.Next.
+func FieldString(data []byte) string {
 	// Find null terminator
 	for i, b := range data {
 		if b == 0 {
@@ -135,18 +149,16 @@ func (f *FloatingFields) GetString(tag uint16) string {
 	return string(data)
 }
 
-// GetUint16 returns a uint16 field.
-func (f *FloatingFields) GetUint16(tag uint16) uint16 {
-	data := f.fields[tag]
+// FieldUint16 decodes the data of a USHORT field, or returns 0 if it is short.
+func FieldUint16(data []byte) uint16 {
 	if len(data) < 2 {
 		return 0
 	}
 	return binary.BigEndian.Uint16(data)
 }
 
-// GetUint32 returns a uint32 field.
-func (f *FloatingFields) GetUint32(tag uint16) uint32 {
-	data := f.fields[tag]
+// FieldUint32 decodes the data of a UINT field, or returns 0 if it is short.
+func FieldUint32(data []byte) uint32 {
 	if len(data) < 4 {
 		return 0
 	}
@@ -183,18 +195,7 @@ func (f *FloatingFields) GetAllStrings(tag uint16) []string {
 	allBytes := f.GetAllBytes(tag)
 	result := make([]string, 0, len(allBytes))
 	for _, data := range allBytes {
-		// Find null terminator
-		s := ""
-		for i, b := range data {
-			if b == 0 {
-				s = string(data[:i])
-				break
-			}
-		}
-		if s == "" && len(data) > 0 {
-			s = string(data)
-		}
-		result = append(result, s)
+		result = append(result, FieldString(data))
 	}
 	return result
 }
@@ -234,6 +235,71 @@ func (f *FloatingFields) Count(tag uint16) int {
 	return count
 }
 
+// GetNamedVariables returns all ECC named variables (Tag 82) keyed by name.
+// Each field is encoded as a null-terminated name followed by a
+// null-terminated value. Returns nil if the message carries none.
+func (f *FloatingFields) GetNamedVariables() map[string]string {
+	var result map[string]string
+	for _, data := range f.GetAllBytes(TagNamedVariable) {
+		name, rest, ok := bytes.Cut(data, []byte{0})
+		if !ok {
+			continue
+		}
+		value, _, _ := bytes.Cut(rest, []byte{0})
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[string(name)] = string(value)
+	}
+	return result
+}
+
+// GetNamedArrays returns all ECC named array elements (Tag 83) keyed by name.
+// Each field is encoded as a null-terminated name, a one-byte index and a
+// null-terminated value. Elements are placed at their index in the returned
+// slice; gaps are left as empty strings. Returns nil if the message carries none.
+func (f *FloatingFields) GetNamedArrays() map[string][]string {
+	var result map[string][]string
+	for _, data := range f.GetAllBytes(TagNamedArray) {
+		name, rest, ok := bytes.Cut(data, []byte{0})
+		if !ok || len(rest) < 1 {
+			continue
+		}
+		index := int(rest[0])
+		value, _, _ := bytes.Cut(rest[1:], []byte{0})
+		if result == nil {
+			result = make(map[string][]string)
+		}
+		values := result[string(name)]
+		for len(values) <= index {
+			values = append(values, "")
+		}
+		values[index] = string(value)
+		result[string(name)] = values
+	}
+	return result
+}
+
+// NamedArrayLen returns the number of named array elements WriteNamedArrays
+// encodes, which is the count carried in a message's NumNamedArrays field.
+func NamedArrayLen(arrays map[string][]string) int {
+	n := 0
+	for _, values := range arrays {
+		for i, v := range values {
+			if encodesNamedArrayElement(i, v) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// encodesNamedArrayElement reports whether a named array element is encoded.
+// Empty elements are gaps, and indexes above 255 do not fit the one-byte index.
+func encodesNamedArrayElement(index int, value string) bool {
+	return value != "" && index <= 255
+}
+
 // FloatingFieldWriter builds the floating part of a message.
 type FloatingFieldWriter struct {
 	buf *Buffer
@@ -281,6 +347,58 @@ func (w *FloatingFieldWriter) WriteUint32(tag uint16, v uint32) {
 	w.WriteBytes(tag, data)
 }
 
+// WriteNamedVariable writes an ECC named variable field (Tag 82).
+func (w *FloatingFieldWriter) WriteNamedVariable(name, value string) {
+	data := make([]byte, 0, len(name)+len(value)+2)
+	data = append(data, name...)
+	data = append(data, 0)
+	data = append(data, value...)
+	data = append(data, 0)
+	w.WriteBytes(TagNamedVariable, data)
+}
+
+// WriteNamedArray writes a single ECC named array element field (Tag 83).
+func (w *FloatingFieldWriter) WriteNamedArray(name string, index uint8, value string) {
+	data := make([]byte, 0, len(name)+len(value)+3)
+	data = append(data, name...)
+	data = append(data, 0, index)
+	data = append(data, value...)
+	data = append(data, 0)
+	w.WriteBytes(TagNamedArray, data)
+}
+
+// WriteNamedVariables writes all named variables, sorted by name so the
+// encoding is deterministic.
+func (w *FloatingFieldWriter) WriteNamedVariables(vars map[string]string) {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.WriteNamedVariable(name, vars[name])
+	}
+}
+
+// WriteNamedArrays writes every non-empty element of the named arrays,
+// sorted by name and then by index. Indexes above 255 cannot be encoded
+// and are skipped, as NamedArrayLen does.
+func (w *FloatingFieldWriter) WriteNamedArrays(arrays map[string][]string) {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for i, v := range arrays[name] {
+			if !encodesNamedArrayElement(i, v) {
+				continue
+			}
+			w.WriteNamedArray(name, uint8(i), v)
+		}
+	}
+}
+
 // Bytes returns the encoded floating fields.
 func (w *FloatingFieldWriter) Bytes() []byte {
 	return w.buf.Bytes()
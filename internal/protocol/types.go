@@ -157,3 +157,120 @@ func SystemEventName(eventID uint32) string {
 		return "Unknown"
 	}
 }
+
+// ControlFailure codes reported in CONTROL_FAILURE_CONF.
+const (
+	ControlFailureGenericUnspecified       uint16 = 0
+	ControlFailureGenericOperation         uint16 = 1
+	ControlFailureRequestIncompatible      uint16 = 2
+	ControlFailureValueOutOfRange          uint16 = 3
+	ControlFailureObjectNotKnown           uint16 = 4
+	ControlFailureInvalidCallingDevice     uint16 = 5
+	ControlFailureInvalidCalledDevice      uint16 = 6
+	ControlFailureInvalidForwardingDest    uint16 = 7
+	ControlFailurePrivilegeViolation       uint16 = 8
+	ControlFailureInvalidCallID            uint16 = 11
+	ControlFailureInvalidDeviceID          uint16 = 12
+	ControlFailureInvalidConnectionID      uint16 = 13
+	ControlFailureInvalidDestination       uint16 = 14
+	ControlFailureInvalidFeature           uint16 = 15
+	ControlFailureSecurityViolation        uint16 = 19
+	ControlFailureGenericStateIncompatible uint16 = 21
+	ControlFailureInvalidObjectState       uint16 = 22
+	ControlFailureInvalidConnectionForCall uint16 = 23
+	ControlFailureNoActiveCall             uint16 = 24
+	ControlFailureNoHeldCall               uint16 = 25
+	ControlFailureNoCallToClear            uint16 = 26
+	ControlFailureNoConnectionToClear      uint16 = 27
+	ControlFailureNoCallToAnswer           uint16 = 28
+	ControlFailureNoCallToComplete         uint16 = 29
+	ControlFailureGenericResourceAvailable uint16 = 31
+	ControlFailureServiceBusy              uint16 = 32
+	ControlFailureResourceBusy             uint16 = 33
+	ControlFailureResourceOutOfService     uint16 = 34
+	ControlFailureNetworkBusy              uint16 = 35
+	ControlFailureNetworkOutOfService      uint16 = 36
+	ControlFailureMonitorLimitExceeded     uint16 = 37
+	ControlFailureConferenceLimitExceeded  uint16 = 38
+	ControlFailureOutstandingLimitExceeded uint16 = 44
+	ControlFailureGenericPerformance       uint16 = 51
+	ControlFailurePerformanceLimitExceeded uint16 = 52
+)
+
+// ControlFailureName returns a human-readable name for a CONTROL_FAILURE_CONF failure code.
+func ControlFailureName(code uint16) string {
+	switch code {
+	case ControlFailureGenericUnspecified:
+		return "GenericUnspecified"
+	case ControlFailureGenericOperation:
+		return "GenericOperation"
+	case ControlFailureRequestIncompatible:
+		return "RequestIncompatibleWithObject"
+	case ControlFailureValueOutOfRange:
+		return "ValueOutOfRange"
+	case ControlFailureObjectNotKnown:
+		return "ObjectNotKnown"
+	case ControlFailureInvalidCallingDevice:
+		return "InvalidCallingDevice"
+	case ControlFailureInvalidCalledDevice:
+		return "InvalidCalledDevice"
+	case ControlFailureInvalidForwardingDest:
+		return "InvalidForwardingDestination"
+	case ControlFailurePrivilegeViolation:
+		return "PrivilegeViolation"
+	case ControlFailureInvalidCallID:
+		return "InvalidCallID"
+	case ControlFailureInvalidDeviceID:
+		return "InvalidDeviceID"
+	case ControlFailureInvalidConnectionID:
+		return "InvalidConnectionID"
+	case ControlFailureInvalidDestination:
+		return "InvalidDestination"
+	case ControlFailureInvalidFeature:
+		return "InvalidFeature"
+	case ControlFailureSecurityViolation:
+		return "SecurityViolation"
+	case ControlFailureGenericStateIncompatible:
+		return "GenericStateIncompatibility"
+	case ControlFailureInvalidObjectState:
+		return "InvalidObjectState"
+	case ControlFailureInvalidConnectionForCall:
+		return "InvalidConnectionIDForActiveCall"
+	case ControlFailureNoActiveCall:
+		return "NoActiveCall"
+	case ControlFailureNoHeldCall:
+		return "NoHeldCall"
+	case ControlFailureNoCallToClear:
+		return "NoCallToClear"
+	case ControlFailureNoConnectionToClear:
+		return "NoConnectionToClear"
+	case ControlFailureNoCallToAnswer:
+		return "NoCallToAnswer"
+	case ControlFailureNoCallToComplete:
+		return "NoCallToComplete"
+	case ControlFailureGenericResourceAvailable:
+		return "GenericSystemResourceAvailability"
+	case ControlFailureServiceBusy:
+		return "ServiceBusy"
+	case ControlFailureResourceBusy:
+		return "ResourceBusy"
+	case ControlFailureResourceOutOfService:
+		return "ResourceOutOfService"
+	case ControlFailureNetworkBusy:
+		return "NetworkBusy"
+	case ControlFailureNetworkOutOfService:
+		return "NetworkOutOfService"
+	case ControlFailureMonitorLimitExceeded:
+		return "OverallMonitorLimitExceeded"
+	case ControlFailureConferenceLimitExceeded:
+		return "ConferenceMemberLimitExceeded"
+	case ControlFailureOutstandingLimitExceeded:
+		return "OutstandingRequestLimitExceeded"
+	case ControlFailureGenericPerformance:
+		return "GenericPerformanceManagement"
+	case ControlFailurePerformanceLimitExceeded:
+		return "PerformanceLimitExceeded"
+	default:
+		return "Unknown"
+	}
+}